@@ -34,7 +34,11 @@ type uniqueName struct {
 }
 
 func (v *uniqueName) Validate(g *graph.Graph, params map[string]interface{}) error {
-	resources, err := g.FindResourcesByProperty("Name", params["name"])
+	name := params["name"]
+	if str, ok := name.(fmt.Stringer); ok {
+		name = str.String()
+	}
+	resources, err := g.FindResourcesByProperty("Name", name)
 	if err != nil {
 		return err
 	}
@@ -42,8 +46,8 @@ func (v *uniqueName) Validate(g *graph.Graph, params map[string]interface{}) err
 	case 0:
 		return nil
 	case 1:
-		return fmt.Errorf("name='%s' is alread used by resource %s[%s]", params["name"], resources[0].Id(), resources[0].Type())
+		return fmt.Errorf("name='%s' is alread used by resource %s[%s]", name, resources[0].Id(), resources[0].Type())
 	default:
-		return fmt.Errorf("name='%s' is alread used by %d resource", params["name"], len(resources))
+		return fmt.Errorf("name='%s' is alread used by %d resource", name, len(resources))
 	}
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/wallix/awless/cloud/aws/validation"
 	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template/ast"
 )
 
 func TestValidation(t *testing.T) {
@@ -31,6 +32,8 @@ func TestValidation(t *testing.T) {
     /instance<inst_2>	"has_type"@[]	"/instance"^^type:text
     /instance<inst_2>	"property"@[]	"{"Key":"Id","Value":"inst_2"}"^^type:text
     /instance<inst_2>	"property"@[]	"{"Key":"Name","Value":"instance2_name"}"^^type:text
+    /instance<inst_3>	"has_type"@[]	"/instance"^^type:text
+    /instance<inst_3>	"property"@[]	"{"Key":"Name","Value":"i-1a2b3c4d"}"^^type:text
     /subnet<sub_1>	"has_type"@[]	"/subnet"^^type:text
     /subnet<sub_1>	"property"@[]	"{"Key":"Id","Value":"sub_1"}"^^type:text
     `))
@@ -43,4 +46,7 @@ func TestValidation(t *testing.T) {
 		t.Fatal("expected not unique")
 	}
 
+	if err := validation.ValidatorsPerActions["create"][0].Validate(g, map[string]interface{}{"name": ast.ResourceID{Prefix: "i", Suffix: "1a2b3c4d"}}); err == nil {
+		t.Fatal("expected not unique")
+	}
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
 	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/ast"
 	"github.com/wallix/awless/template/driver/aws"
 )
 
@@ -74,6 +75,10 @@ var runCmd = &cobra.Command{
 }
 
 func runTemplate(templ *template.Template, defaults map[string]interface{}) error {
+	if err := validateTemplate(templ); err != nil {
+		return err
+	}
+
 	resolved, err := templ.ResolveHoles(defaults)
 	exitOn(err)
 	logger.Infof("used default params: %s (list and set defaults with `awless config`)", sprintProcessedParams(resolved))
@@ -81,10 +86,15 @@ func runTemplate(templ *template.Template, defaults map[string]interface{}) erro
 	fills := make(map[string]interface{})
 	if holes := templ.GetHolesValuesSet(); len(holes) > 0 {
 		fmt.Println("\nMissing required params (Ctrl+C to quit):")
+		types := templ.GetHolesTypes()
 		for _, hole := range holes {
 			var resp string
 			ask := func() error {
-				fmt.Printf("%s ? ", hole)
+				if typ, ok := types[hole]; ok {
+					fmt.Printf("%s (%s) ? ", hole, typ)
+				} else {
+					fmt.Printf("%s ? ", hole)
+				}
 				_, err := fmt.Scanln(&resp)
 				return err
 			}
@@ -96,7 +106,8 @@ func runTemplate(templ *template.Template, defaults map[string]interface{}) erro
 	}
 
 	if len(fills) > 0 {
-		templ.ResolveHoles(fills)
+		_, err = templ.ResolveHoles(fills)
+		exitOn(err)
 	}
 
 	awsDriver := aws.NewDriver(
@@ -151,6 +162,23 @@ func runTemplate(templ *template.Template, defaults map[string]interface{}) erro
 	return nil
 }
 
+// validateTemplate checks the template against the aws definitions,
+// logging each error found
+func validateTemplate(templ *template.Template) error {
+	errs := templ.ValidateAgainst(aws.AWSTemplatesDefinitions)
+	for _, err := range errs {
+		if verr, ok := err.(*ast.ValidationError); ok {
+			logger.Errorf("line %d: %s", verr.Line, verr)
+		} else {
+			logger.Error(err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid template: %d error(s)", len(errs))
+	}
+	return nil
+}
+
 func createDriverCommands(action string, entities []string) *cobra.Command {
 	actionCmd := &cobra.Command{
 		Use:   action,
@@ -0,0 +1,41 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/wallix/awless/template"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	tcases := []struct {
+		input string
+		valid bool
+	}{
+		{input: "create vpc cidr=10.0.0.0/16", valid: true},
+		{input: "craete vpc cidr=10.0.0.0/16", valid: false},
+		{input: "create vppc cidr=10.0.0.0/16", valid: false},
+		{input: "create vpc cidr=10.0.0.0/16\ndelete vppc id=vpc-12345678", valid: false},
+	}
+	for _, tcase := range tcases {
+		err := validateTemplate(template.MustParse(tcase.input))
+		if got, want := err == nil, tcase.valid; got != want {
+			t.Fatalf("%s: got %v, want valid %t", tcase.input, err, want)
+		}
+	}
+}
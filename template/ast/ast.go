@@ -17,71 +17,206 @@ limitations under the License.
 package ast
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math"
 	"net"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
 )
 
 type Node interface {
 	clone() Node
+	equal(Node) bool
 	String() string
 }
 
 type Statement struct {
 	Node
-	Result interface{}
-	Line   string
-	Err    error
+	Guard      *Guard
+	Result     interface{}
+	Line       string
+	LineNumber int
+	// BlankLines is the number of blank lines right above the statement in
+	// the source, reproduced between statements when printing the template
+	BlankLines int
+	// DisabledChecks are the validation checks suppressed on the statement
+	// by an "# awless:disable check1,check2" comment right above it
+	DisabledChecks []string
+	Err            error
+	// Meta holds annotations of tools working on the template
+	// (ex: cost estimates), deep copied on clone
+	Meta map[string]interface{}
 }
 
 func (s *Statement) clone() *Statement {
 	newStat := &Statement{}
 	newStat.Node = s.Node.clone()
+	if s.Guard != nil {
+		guard := *s.Guard
+		newStat.Guard = &guard
+	}
 	newStat.Result = s.Result
+	newStat.LineNumber = s.LineNumber
+	newStat.BlankLines = s.BlankLines
+	newStat.DisabledChecks = append([]string(nil), s.DisabledChecks...)
 	newStat.Err = s.Err
+	if s.Meta != nil {
+		newStat.Meta = cloneValue(s.Meta).(map[string]interface{})
+	}
 
 	return newStat
 }
 
-func (s *Statement) Action() string {
-	switch n := s.Node.(type) {
-	case *ExpressionNode:
-		return n.Action
-	case *DeclarationNode:
-		return n.Right.Action
+// Disables reports whether the check is disabled on the statement
+func (s *Statement) Disables(check string) bool {
+	for _, c := range s.DisabledChecks {
+		if c == check {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Statement) String() string {
+	if s.Guard != nil {
+		return fmt.Sprintf("when %s %s", s.Guard, s.Node)
+	}
+	return s.Node.String()
+}
+
+// StatementKind tells apart the different kinds of statements
+type StatementKind int
+
+const (
+	KindExpression StatementKind = iota
+	KindDeclaration
+	KindVar
+	KindInclude
+)
+
+func (k StatementKind) String() string {
+	switch k {
+	case KindExpression:
+		return "expression"
+	case KindDeclaration:
+		return "declaration"
+	case KindVar:
+		return "var"
+	case KindInclude:
+		return "include"
 	default:
-		panic(fmt.Sprintf("unknown type of node %T", s.Node))
+		return "unknown"
 	}
 }
 
-func (s *Statement) Entity() string {
-	switch n := s.Node.(type) {
+func (s *Statement) Kind() StatementKind {
+	switch s.Node.(type) {
 	case *ExpressionNode:
-		return n.Entity
+		return KindExpression
 	case *DeclarationNode:
-		return n.Right.Entity
+		return KindDeclaration
+	case *IncludeNode:
+		return KindInclude
+	case *VarNode:
+		return KindVar
 	default:
 		panic(fmt.Sprintf("unknown type of node %T", s.Node))
 	}
 }
 
+func (s *Statement) Action() string {
+	return s.expression().Action
+}
+
+func (s *Statement) Entity() string {
+	return s.expression().Entity
+}
+
 func (s *Statement) Params() map[string]interface{} {
-	switch n := s.Node.(type) {
-	case *ExpressionNode:
-		return n.Params
-	case *DeclarationNode:
-		return n.Right.Params
+	return s.expression().Params
+}
+
+// expression returns the expression run by the statement
+func (s *Statement) expression() *ExpressionNode {
+	switch s.Kind() {
+	case KindDeclaration:
+		return s.Node.(*DeclarationNode).Right
+	case KindInclude, KindVar:
+		// includes run nothing until spliced by the caller,
+		// vars only hold a value
+		return &ExpressionNode{}
 	default:
-		panic(fmt.Sprintf("unknown type of node %T", s.Node))
+		return s.Node.(*ExpressionNode)
+	}
+}
+
+// KeyValue is a param of a statement with its value as written in templates
+type KeyValue struct {
+	Key, Value string
+}
+
+// SortedParams returns all the params of the statement sorted by key,
+// whether values, references ($x), aliases (@x), holes ({x})
+// or environment variables (${ENV:X})
+func (s *Statement) SortedParams() []KeyValue {
+	return s.expression().sortedParams()
+}
+
+func (n *ExpressionNode) sortedParams() (params []KeyValue) {
+	for k, v := range n.Params {
+		params = append(params, KeyValue{k, n.printValue(k, v)})
+	}
+	for k, v := range n.Refs {
+		if !n.isItem(k) {
+			params = append(params, KeyValue{k, printRef(v)})
+		}
+	}
+	for k, v := range n.Aliases {
+		if !n.isItem(k) {
+			params = append(params, KeyValue{k, printAlias(v)})
+		}
+	}
+	for k := range n.Holes {
+		if !n.isItem(k) {
+			params = append(params, KeyValue{k, n.printHole(k)})
+		}
+	}
+	for k, v := range n.Envs {
+		params = append(params, KeyValue{k, fmt.Sprintf("${ENV:%s}", v)})
 	}
+	sort.Sort(byKey(params))
+	return
 }
 
+type byKey []KeyValue
+
+func (b byKey) Len() int           { return len(b) }
+func (b byKey) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byKey) Less(i, j int) bool { return b[i].Key < b[j].Key }
+
 type AST struct {
 	Statements []*Statement
 
 	currentStatement *Statement
 	currentKey       string
+	lineCursor       int
+	lineCount        int
+	limits           *Limits
+	paramsCount      int
+	valueErrs        ParseErrors
+	listKey          string
+	guard            *Guard
+	disabledChecks   []string
+	with             *ExpressionNode
+	withStart        int
+	varValue         *ExpressionNode
 }
 
 func (a *AST) String() string {
@@ -89,7 +224,87 @@ func (a *AST) String() string {
 	for _, stat := range a.Statements {
 		all = append(all, stat.String())
 	}
-	return strings.Join(all, "\n")
+	return a.joinLines(all)
+}
+
+// joinLines joins the printed statements keeping the blank lines between them
+func (a *AST) joinLines(lines []string) string {
+	var buff bytes.Buffer
+	for i, line := range lines {
+		if i > 0 {
+			buff.WriteString(strings.Repeat("\n", a.Statements[i].BlankLines+1))
+		}
+		if checks := a.Statements[i].DisabledChecks; len(checks) > 0 {
+			fmt.Fprintf(&buff, "%s %s\n", disableDirective, strings.Join(checks, ","))
+		}
+		buff.WriteString(line)
+	}
+	return buff.String()
+}
+
+// StringRedacted is like String but prints *** as the value of the params
+// of the given keys (ex: password). Refs, aliases, holes and environment
+// variables of these keys are printed as they hold no secret.
+func (a *AST) StringRedacted(sensitiveKeys []string) string {
+	clone := a.Clone()
+	for _, expr := range clone.expressionNodes() {
+		for _, key := range sensitiveKeys {
+			if _, ok := expr.Params[key]; !ok {
+				continue
+			}
+			expr.Params[key] = redacted{}
+			for _, m := range []map[string]string{expr.Refs, expr.Aliases, expr.Holes} {
+				for k := range m {
+					if strings.HasPrefix(k, key+"[") {
+						delete(m, k)
+					}
+				}
+			}
+		}
+	}
+	return clone.String()
+}
+
+type redacted struct{}
+
+func (redacted) String() string { return "***" }
+
+// RawString is like String but prints the params parsed from
+// a template with their exact source text
+func (a *AST) RawString() string {
+	var all []string
+	for _, stat := range a.Statements {
+		var line string
+		switch n := stat.Node.(type) {
+		case *DeclarationNode:
+			line = fmt.Sprintf("%s = %s", n.idents(), n.Right.RawString())
+		case *ExpressionNode:
+			line = n.RawString()
+		default:
+			line = n.String()
+		}
+		if stat.Guard != nil {
+			line = fmt.Sprintf("when %s %s", stat.Guard, line)
+		}
+		all = append(all, line)
+	}
+	return a.joinLines(all)
+}
+
+// Filter returns the statements running the given action on the given
+// entity, an empty action or entity matching any
+func (a *AST) Filter(action, entity string) (stats []*Statement) {
+	for _, st := range a.Statements {
+		switch st.Node.(type) {
+		case *ExpressionNode, *DeclarationNode:
+		default:
+			continue
+		}
+		if (action == "" || st.Action() == action) && (entity == "" || st.Entity() == entity) {
+			stats = append(stats, st)
+		}
+	}
+	return
 }
 
 type IdentifierNode struct {
@@ -104,24 +319,97 @@ func (n *IdentifierNode) String() string {
 func (n *IdentifierNode) clone() Node {
 	return &IdentifierNode{
 		Ident: n.Ident,
-		Val:   n.Val,
+		Val:   cloneValue(n.Val),
 	}
 }
 
 type DeclarationNode struct {
-	Left  *IdentifierNode
+	Left *IdentifierNode
+	// Extra holds the identifiers following Left when the expression
+	// outputs several values (ex: mykey, mysecret = create accesskey)
+	Extra []*IdentifierNode
 	Right *ExpressionNode
 }
 
+// Idents returns all the identifiers declared, in order
+func (n *DeclarationNode) Idents() []*IdentifierNode {
+	return append([]*IdentifierNode{n.Left}, n.Extra...)
+}
+
+func (n *DeclarationNode) idents() string {
+	var names []string
+	for _, ident := range n.Idents() {
+		names = append(names, quoteName(ident.Ident))
+	}
+	return strings.Join(names, ", ")
+}
+
 func (n *DeclarationNode) clone() Node {
-	return &DeclarationNode{
+	decl := &DeclarationNode{
 		Left:  n.Left.clone().(*IdentifierNode),
 		Right: n.Right.clone().(*ExpressionNode),
 	}
+	for _, ident := range n.Extra {
+		decl.Extra = append(decl.Extra, ident.clone().(*IdentifierNode))
+	}
+	return decl
 }
 
 func (n *DeclarationNode) String() string {
-	return fmt.Sprintf("%s = %s", n.Left, n.Right)
+	return fmt.Sprintf("%s = %s", n.idents(), n.Right)
+}
+
+// IncludeNode is an include of another template (ex: include "vpc.aws").
+// The parser does not read the file: callers resolve the path and splice
+// the included statements, the line of the include being on its statement.
+type IncludeNode struct {
+	Path string
+}
+
+func (n *IncludeNode) clone() Node {
+	return &IncludeNode{Path: n.Path}
+}
+
+func (n *IncludeNode) equal(other Node) bool {
+	o, ok := other.(*IncludeNode)
+	return ok && n.Path == o.Path
+}
+
+func (n *IncludeNode) String() string {
+	return fmt.Sprintf("include %s", strconv.Quote(n.Path))
+}
+
+// VarNode declares a literal value referenced by the following
+// statements (ex: var region=us-east-1 then $region)
+type VarNode struct {
+	Ident string
+	Value interface{}
+}
+
+func (n *VarNode) clone() Node {
+	return &VarNode{Ident: n.Ident, Value: cloneValue(n.Value)}
+}
+
+func (n *VarNode) equal(other Node) bool {
+	o, ok := other.(*VarNode)
+	return ok && n.Ident == o.Ident && reflect.DeepEqual(n.Value, o.Value)
+}
+
+func (n *VarNode) String() string {
+	return fmt.Sprintf("var %s=%s", quoteName(n.Ident), printParamValue(n.Value))
+}
+
+// declaredIdents returns the identifiers declared by the statement
+func (s *Statement) declaredIdents() (idents []string) {
+	switch n := s.Node.(type) {
+	case *DeclarationNode:
+		for _, ident := range n.Idents() {
+			idents = append(idents, ident.Ident)
+		}
+	case *VarNode:
+		idents = append(idents, n.Ident)
+	}
+	return
 }
 
 type ExpressionNode struct {
@@ -130,6 +418,23 @@ type ExpressionNode struct {
 	Params         map[string]interface{}
 	Aliases        map[string]string
 	Holes          map[string]string
+	HoleTypes      map[string]string
+	Envs           map[string]string
+	// Matches flags the params given as regex patterns to match
+	// (ex: name=~^web-) rather than values to be equal to
+	Matches map[string]bool
+	// Modifiers are the keywords qualifying the statement, acted on by
+	// consumers (ex: delete vpc id=$myvpc cascade)
+	Modifiers map[string]bool
+	// Flags are the boolean options of the statement in source order
+	// (ex: --force in delete instance --force id=@web)
+	Flags []string
+	// Raw holds the source text of the param values parsed from a
+	// template (ex: 0x1F), dropped once a value is filled or changed
+	Raw map[string]string
+
+	// holeKeys keeps the keys of the holes in source order
+	holeKeys []string
 }
 
 func (n *ExpressionNode) clone() Node {
@@ -145,7 +450,7 @@ func (n *ExpressionNode) clone() Node {
 		expr.Refs[k] = v
 	}
 	for k, v := range n.Params {
-		expr.Params[k] = v
+		expr.Params[k] = cloneValue(v)
 	}
 	for k, v := range n.Aliases {
 		expr.Aliases[k] = v
@@ -153,165 +458,1821 @@ func (n *ExpressionNode) clone() Node {
 	for k, v := range n.Holes {
 		expr.Holes[k] = v
 	}
+	if n.HoleTypes != nil {
+		expr.HoleTypes = make(map[string]string)
+		for k, v := range n.HoleTypes {
+			expr.HoleTypes[k] = v
+		}
+	}
+	if n.Envs != nil {
+		expr.Envs = make(map[string]string)
+		for k, v := range n.Envs {
+			expr.Envs[k] = v
+		}
+	}
+	if n.Matches != nil {
+		expr.Matches = make(map[string]bool)
+		for k, v := range n.Matches {
+			expr.Matches[k] = v
+		}
+	}
+	if n.Modifiers != nil {
+		expr.Modifiers = make(map[string]bool)
+		for k, v := range n.Modifiers {
+			expr.Modifiers[k] = v
+		}
+	}
+	expr.Flags = append(expr.Flags, n.Flags...)
+	expr.holeKeys = append(expr.holeKeys, n.holeKeys...)
+	if n.Raw != nil {
+		expr.Raw = make(map[string]string)
+		for k, v := range n.Raw {
+			expr.Raw[k] = v
+		}
+	}
 
 	return expr
 }
 
+// cloneValue deep copies slice and map values so that clones
+// never share their backing store with the original
+func cloneValue(v interface{}) interface{} {
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Slice:
+		if val.IsNil() {
+			return v
+		}
+		clone := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		for i := 0; i < val.Len(); i++ {
+			if elem := val.Index(i); elem.CanInterface() && elem.Interface() != nil {
+				clone.Index(i).Set(reflect.ValueOf(cloneValue(elem.Interface())))
+			}
+		}
+		return clone.Interface()
+	case reflect.Map:
+		if val.IsNil() {
+			return v
+		}
+		clone := reflect.MakeMap(val.Type())
+		for _, k := range val.MapKeys() {
+			if elem := val.MapIndex(k); elem.Interface() != nil {
+				clone.SetMapIndex(k, reflect.ValueOf(cloneValue(elem.Interface())))
+			} else {
+				clone.SetMapIndex(k, elem)
+			}
+		}
+		return clone.Interface()
+	default:
+		return v
+	}
+}
+
 func (n *ExpressionNode) String() string {
+	return n.format(false)
+}
+
+// RawString is like String but prints the params parsed from
+// a template with their exact source text (ex: port=0x1F)
+func (n *ExpressionNode) RawString() string {
+	return n.format(true)
+}
+
+// HasFlag reports whether the flag is set on the expression (ex: "force")
+func (n *ExpressionNode) HasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *ExpressionNode) format(preserveRaw bool) string {
 	var all []string
+	add := func(k, v string) {
+		if raw, ok := n.Raw[k]; ok && preserveRaw {
+			v = raw
+		}
+		all = append(all, fmt.Sprintf("%s=%s", k, v))
+	}
 	for k, v := range n.Refs {
-		all = append(all, fmt.Sprintf("%s=$%v", k, v))
+		if !n.isItem(k) {
+			add(k, printRef(v))
+		}
 	}
 	for k, v := range n.Params {
-		all = append(all, fmt.Sprintf("%s=%v", k, v))
+		add(k, n.printValue(k, v))
 	}
 	for k, v := range n.Aliases {
-		all = append(all, fmt.Sprintf("%s=@%s", k, v))
+		if !n.isItem(k) {
+			add(k, printAlias(v))
+		}
 	}
-	for k, v := range n.Holes {
-		all = append(all, fmt.Sprintf("%s={%s}", k, v))
+	for k := range n.Holes {
+		if !n.isItem(k) {
+			add(k, n.printHole(k))
+		}
+	}
+	for k, v := range n.Envs {
+		add(k, fmt.Sprintf("${ENV:%s}", v))
+	}
+	for _, flag := range n.Flags {
+		all = append(all, "--"+flag)
+	}
+	all = append(all, sortedKeys(n.Modifiers)...)
+	if len(all) == 0 {
+		return fmt.Sprintf("%s %s", n.Action, n.Entity)
 	}
 	return fmt.Sprintf("%s %s %s", n.Action, n.Entity, strings.Join(all, " "))
 }
 
-func (n *ExpressionNode) ProcessHoles(fills map[string]interface{}) map[string]interface{} {
-	processed := make(map[string]interface{})
-	if n.Params == nil {
-		n.Params = make(map[string]interface{})
+// printValue prints a param value, with the refs, aliases
+// and holes of its items when the value is a list
+func (n *ExpressionNode) printValue(key string, v interface{}) string {
+	if n.Matches[key] {
+		return "~" + printPattern(fmt.Sprint(v))
 	}
-	for key, hole := range n.Holes {
-		if val, ok := fills[hole]; ok {
-			n.Params[key] = val
-			processed[key] = val
-			delete(n.Holes, key)
+	if p, ok := v.(PercentOf); ok {
+		if ref, ok := n.Refs[listItemKey(key, 0)]; ok {
+			p.Ref = ref
 		}
+		return p.String()
 	}
-	return processed
-}
-
-func (n *ExpressionNode) ProcessRefs(fills map[string]interface{}) {
-	if n.Params == nil {
-		n.Params = make(map[string]interface{})
+	list, ok := v.([]interface{})
+	if !ok {
+		return printParamValue(v)
 	}
-	for key, ref := range n.Refs {
-		if val, ok := fills[ref]; ok {
-			n.Params[key] = val
-			delete(n.Refs, key)
+	var items []string
+	for i, item := range list {
+		itemKey := listItemKey(key, i)
+		if ref, ok := n.Refs[itemKey]; ok {
+			items = append(items, printRef(ref))
+		} else if alias, ok := n.Aliases[itemKey]; ok {
+			items = append(items, printAlias(alias))
+		} else if _, ok := n.Holes[itemKey]; ok {
+			items = append(items, n.printHole(itemKey))
+		} else {
+			items = append(items, printParamValue(item))
 		}
 	}
+	return "[" + strings.Join(items, ",") + "]"
 }
 
-func (s *AST) AddAction(text string) {
-	expr := s.currentExpression()
-	if expr == nil {
-		s.addStatement(&ExpressionNode{Action: text})
-	} else {
-		expr.Action = text
+func (n *ExpressionNode) printHole(key string) string {
+	if typ, ok := n.HoleTypes[key]; ok {
+		return fmt.Sprintf("{%s:%s}", quoteName(n.Holes[key]), typ)
 	}
+	return fmt.Sprintf("{%s}", quoteName(n.Holes[key]))
 }
 
-func (s *AST) AddEntity(text string) {
-	expr := s.currentExpression()
-	expr.Entity = text
+// printPattern single quotes regex patterns that cannot be written bare
+func printPattern(pattern string) string {
+	if pattern == "" || strings.HasPrefix(pattern, "'") || strings.ContainsAny(pattern, " \t\r\n;") {
+		return "'" + pattern + "'"
+	}
+	return pattern
 }
 
-func (s *AST) AddDeclarationIdentifier(text string) {
-	decl := &DeclarationNode{
-		Left:  &IdentifierNode{Ident: text},
-		Right: &ExpressionNode{},
+func printRef(name string) string {
+	return "$" + quoteName(name)
+}
+
+func printAlias(name string) string {
+	if plainAlias.MatchString(name) {
+		return "@" + name
 	}
-	s.addStatement(decl)
+	return "@" + strconv.Quote(name)
 }
 
-func (s *AST) LineDone() {
-	s.currentStatement = nil
-	s.currentKey = ""
+var (
+	plainName  = regexp.MustCompile(`^` + plainNamePattern + `$`)
+	plainAlias = regexp.MustCompile(`^` + plainNamePattern + `([/:]` + plainNamePattern + `)*$`)
+)
+
+// plainNamePattern matches the names of the grammar: not only digits
+const plainNamePattern = `[a-zA-Z0-9-_.]*[a-zA-Z-_.][a-zA-Z0-9-_.]*`
+
+// quoteName double quotes declaration identifiers, refs, aliases and hole
+// names that cannot be written as is (ex: "my resource")
+func quoteName(name string) string {
+	if plainName.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
 }
 
-func (s *AST) AddParamKey(text string) {
-	expr := s.currentExpression()
-	if expr.Params == nil {
-		expr.Refs = make(map[string]string)
-		expr.Params = make(map[string]interface{})
-		expr.Aliases = make(map[string]string)
-		expr.Holes = make(map[string]string)
+// unquoteName strips the double quotes of a quoted name as parsed
+func unquoteName(text string) string {
+	if strings.HasPrefix(text, `"`) {
+		if name, err := strconv.Unquote(text); err == nil {
+			return name
+		}
 	}
-	s.currentKey = text
+	return text
 }
 
-func (s *AST) AddParamValue(text string) {
-	expr := s.currentExpression()
-	expr.Params[s.currentKey] = text
+var bareStringValue = regexp.MustCompile("^[a-zA-Z0-9-._:/]+$")
+
+func printParamValue(v interface{}) string {
+	switch vv := v.(type) {
+	case float64:
+		return printFloat(vv)
+	case []string:
+		return strings.Join(vv, ",")
+	case []interface{}:
+		var items []string
+		for _, item := range vv {
+			items = append(items, printParamValue(item))
+		}
+		return "[" + strings.Join(items, ",") + "]"
+	case Interpolation:
+		return vv.quoted()
+	}
+	str, ok := v.(string)
+	if !ok || (bareStringValue.MatchString(str) && !isTypedBareValue(str)) {
+		return fmt.Sprint(v)
+	}
+	if !strings.Contains(str, "'") {
+		return "'" + str + "'"
+	}
+	return quoteEscapingBraces(str)
 }
 
-func (s *AST) AddParamIntValue(text string) {
-	expr := s.currentExpression()
-	num, err := strconv.Atoi(text)
+// parseFloat parses decimal floats with optional exponent (ex: 1.5, 1.5e9, 2E-3)
+func parseFloat(text string) (float64, error) {
+	f, err := strconv.ParseFloat(text, 64)
+	if nerr, ok := err.(*strconv.NumError); ok && nerr.Err == strconv.ErrRange {
+		return 0, fmt.Errorf("float '%s' out of range", text)
+	}
 	if err != nil {
-		panic(fmt.Sprintf("cannot convert '%s' to int", text))
+		return 0, fmt.Errorf("cannot convert '%s' to float", text)
 	}
-	expr.Params[s.currentKey] = num
+	return f, nil
 }
 
-func (s *AST) AddParamCidrValue(text string) {
-	expr := s.currentExpression()
-	_, ipnet, err := net.ParseCIDR(text)
-	if err != nil {
-		panic(fmt.Sprintf("cannot convert '%s' to net cidr", text))
+// printFloat always includes a decimal point or an exponent
+// so that floats are not parsed back as ints
+func printFloat(f float64) string {
+	str := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(str, ".e") {
+		str += ".0"
 	}
-	expr.Params[s.currentKey] = ipnet.String()
+	return str
 }
 
-func (s *AST) AddParamIpValue(text string) {
-	expr := s.currentExpression()
-	ip := net.ParseIP(text)
-	if ip == nil {
-		panic(fmt.Sprintf("cannot convert '%s' to net ip", text))
+var ipShapeValue = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+\.[0-9]+(/[0-9]+)?$`)
+
+// isTypedBareValue reports whether a string printed bare would not be parsed
+// back as the same string (ex: "80" as an int), in which case it gets quoted
+func isTypedBareValue(str string) bool {
+	if str[0] >= '0' && str[0] <= '9' {
+		// cidrs and ips are kept as strings when already normalized
+		if ipShapeValue.MatchString(str) {
+			if _, ipnet, err := net.ParseCIDR(str); err == nil {
+				return ipnet.String() != str
+			}
+			if ip := net.ParseIP(str); ip != nil {
+				return ip.String() != str
+			}
+			return true
+		}
+		// hex, octal (0o), ints, floats, ranges and sizes, the grammar
+		// having no bare string value starting with a digit
+		return true
 	}
-	expr.Params[s.currentKey] = ip.String()
+	if _, err := parseRange(str); err == nil {
+		return true
+	}
+	if _, err := parseARN(str); err == nil {
+		return true
+	}
+	if _, err := parseResourceID(str); err == nil {
+		return true
+	}
+	return str == Null.String()
 }
 
-func (s *AST) AddParamRefValue(text string) {
-	expr := s.currentExpression()
-	expr.Refs[s.currentKey] = text
+func (n *ExpressionNode) ProcessHoles(fills map[string]interface{}) (map[string]interface{}, error) {
+	processed := make(map[string]interface{})
+	if n.Params == nil {
+		n.Params = make(map[string]interface{})
+	}
+	var err error
+	for key, hole := range n.Holes {
+		if val, ok := fills[hole]; ok {
+			if typ, typed := n.HoleTypes[key]; typed {
+				converted, cerr := convertHoleValue(typ, val)
+				if cerr != nil {
+					if err == nil {
+						err = fmt.Errorf("hole '%s': %s", hole, cerr)
+					}
+					continue
+				}
+				val = converted
+				delete(n.HoleTypes, key)
+			}
+			n.Params[key] = val
+			delete(n.Raw, key)
+			processed[key] = val
+			delete(n.Holes, key)
+		}
+	}
+	n.mergeItems()
+	return processed, err
 }
 
-func (s *AST) AddParamAliasValue(text string) {
-	expr := s.currentExpression()
-	expr.Aliases[s.currentKey] = text
+func convertHoleValue(typ string, val interface{}) (interface{}, error) {
+	str := fmt.Sprint(val)
+	switch typ {
+	case "string":
+		return val, nil
+	case "int":
+		if _, ok := val.(int); ok {
+			return val, nil
+		}
+		num, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, fmt.Errorf("expected int value, got '%s'", str)
+		}
+		return num, nil
+	case "cidr":
+		_, ipnet, err := net.ParseCIDR(str)
+		if err != nil {
+			return nil, fmt.Errorf("expected cidr value, got '%s'", str)
+		}
+		return ipnet.String(), nil
+	case "ip":
+		ip := net.ParseIP(str)
+		if ip == nil {
+			return nil, fmt.Errorf("expected ip value, got '%s'", str)
+		}
+		return ip.String(), nil
+	default:
+		return nil, fmt.Errorf("unknown hole type '%s'", typ)
+	}
 }
 
-func (s *AST) AddParamHoleValue(text string) {
-	expr := s.currentExpression()
-	expr.Holes[s.currentKey] = text
+func listItemKey(key string, index int) string {
+	return fmt.Sprintf("%s[%d]", key, index)
 }
 
-func (s *AST) currentExpression() *ExpressionNode {
-	st := s.currentStatement
-	if st == nil {
-		return nil
-	}
+var listItemKeyRegex = regexp.MustCompile(`^(.+)\[([0-9]+)\]$`)
 
-	switch st.Node.(type) {
-	case *ExpressionNode:
-		return st.Node.(*ExpressionNode)
-	case *DeclarationNode:
-		return st.Node.(*DeclarationNode).Right
-	default:
-		panic("last expression: unexpected node type")
+// listItem reports whether the param key designates an item of a list param
+func (n *ExpressionNode) listItem(key string) (list []interface{}, index int, ok bool) {
+	matches := listItemKeyRegex.FindStringSubmatch(key)
+	if matches == nil {
+		return
 	}
+	list, ok = n.Params[matches[1]].([]interface{})
+	index, _ = strconv.Atoi(matches[2])
+	return list, index, ok && index < len(list)
 }
 
-func (a *AST) Clone() *AST {
-	clone := &AST{}
-	for _, stat := range a.Statements {
-		clone.Statements = append(clone.Statements, stat.clone())
+// hasKey reports whether the param key is set, whatever its kind of value
+func (n *ExpressionNode) hasKey(key string) bool {
+	if _, ok := n.Params[key]; ok {
+		return true
+	}
+	for _, m := range []map[string]string{n.Refs, n.Aliases, n.Holes, n.Envs} {
+		if _, ok := m[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// interpolationPart reports whether the param key designates
+// a hole embedded in an interpolated string param
+func (n *ExpressionNode) interpolationPart(key string) (in Interpolation, index int, ok bool) {
+	matches := listItemKeyRegex.FindStringSubmatch(key)
+	if matches == nil {
+		return
+	}
+	in, ok = n.Params[matches[1]].(Interpolation)
+	index, _ = strconv.Atoi(matches[2])
+	return in, index, ok && index < len(in)
+}
+
+// isItem reports whether the param key designates an item of a list, a hole
+// of an interpolated string or the ref of a percentage, printed with their param
+func (n *ExpressionNode) isItem(key string) bool {
+	if _, _, ok := n.listItem(key); ok {
+		return true
+	}
+	if _, ok := n.percentOfRef(key); ok {
+		return true
+	}
+	_, _, ok := n.interpolationPart(key)
+	return ok
+}
+
+// percentOfRef reports whether the param key designates the ref
+// of a percentage value (ex: capacity[0] for capacity=50%of$max)
+func (n *ExpressionNode) percentOfRef(key string) (PercentOf, bool) {
+	matches := listItemKeyRegex.FindStringSubmatch(key)
+	if matches == nil || matches[2] != "0" {
+		return PercentOf{}, false
+	}
+	p, ok := n.Params[matches[1]].(PercentOf)
+	return p, ok
+}
+
+// mergeItems moves the values of list items, once resolved, into their list
+// and renders the interpolated strings whose holes are all filled
+func (n *ExpressionNode) mergeItems() {
+	for key, val := range n.Params {
+		base := strings.SplitN(key, "[", 2)[0]
+		if list, index, ok := n.listItem(key); ok {
+			list[index] = val
+			delete(n.Params, key)
+			delete(n.Raw, base)
+		} else if p, ok := n.percentOfRef(key); ok {
+			p.Of = val
+			n.Params[base] = p
+			delete(n.Params, key)
+			delete(n.Raw, base)
+		} else if in, index, ok := n.interpolationPart(key); ok {
+			in[index] = InterpolationPart{Text: fmt.Sprint(val)}
+			delete(n.Params, key)
+			delete(n.Raw, base)
+			if in.filled() {
+				n.Params[base] = in.String()
+			}
+		}
+	}
+}
+
+// inherit sets the params of a with block that the expression does not set
+func (n *ExpressionNode) inherit(block *ExpressionNode) {
+	base := func(key string) string { return strings.SplitN(key, "[", 2)[0] }
+	set := make(map[string]bool)
+	for _, m := range []map[string]string{n.Refs, n.Aliases, n.Holes, n.Envs} {
+		for k := range m {
+			set[base(k)] = true
+		}
+	}
+	for k := range n.Params {
+		set[base(k)] = true
+	}
+
+	b := block.clone().(*ExpressionNode)
+	if n.Params == nil {
+		n.Refs = make(map[string]string)
+		n.Params = make(map[string]interface{})
+		n.Aliases = make(map[string]string)
+		n.Holes = make(map[string]string)
+	}
+	for k, v := range b.Params {
+		if !set[base(k)] {
+			n.Params[k] = v
+		}
+	}
+	for k, v := range b.Refs {
+		if !set[base(k)] {
+			n.Refs[k] = v
+		}
+	}
+	for k, v := range b.Aliases {
+		if !set[base(k)] {
+			n.Aliases[k] = v
+		}
+	}
+	var holeKeys []string
+	for _, k := range b.holeKeys {
+		if set[base(k)] {
+			continue
+		}
+		n.Holes[k] = b.Holes[k]
+		holeKeys = append(holeKeys, k)
+		if typ, ok := b.HoleTypes[k]; ok {
+			if n.HoleTypes == nil {
+				n.HoleTypes = make(map[string]string)
+			}
+			n.HoleTypes[k] = typ
+		}
+	}
+	n.holeKeys = append(holeKeys, n.holeKeys...)
+	for k, v := range b.Envs {
+		if !set[base(k)] {
+			if n.Envs == nil {
+				n.Envs = make(map[string]string)
+			}
+			n.Envs[k] = v
+		}
+	}
+}
+
+// ProcessRefs fills the refs found in fills and returns
+// the sorted keys of the refs left unresolved
+func (n *ExpressionNode) ProcessRefs(fills map[string]interface{}) (unresolved []string) {
+	if n.Params == nil {
+		n.Params = make(map[string]interface{})
+	}
+	for key, ref := range n.Refs {
+		if val, ok := fills[ref]; ok {
+			n.Params[key] = val
+			delete(n.Raw, key)
+			delete(n.Refs, key)
+			continue
+		}
+		path := ParseRefPath(ref)
+		if path.Attr != "" {
+			if val, ok := fills[path.Name]; ok {
+				if attr, found := extractAttribute(val, strings.Split(path.Attr, ".")); found {
+					n.Params[key] = attr
+					delete(n.Raw, key)
+					delete(n.Refs, key)
+					continue
+				}
+			}
+		}
+		unresolved = append(unresolved, key)
+	}
+	n.mergeItems()
+	sort.Strings(unresolved)
+	return
+}
+
+// ProcessAllRefs fills the refs of all statements and returns the refs
+// left unresolved as "entity.key", in statement order
+func (a *AST) ProcessAllRefs(fills map[string]interface{}) (unresolved []string) {
+	for _, st := range a.Statements {
+		switch st.Node.(type) {
+		case *ExpressionNode, *DeclarationNode:
+		default:
+			continue
+		}
+		expr := st.expression()
+		for _, key := range expr.ProcessRefs(fills) {
+			unresolved = append(unresolved, expr.Entity+"."+key)
+		}
+	}
+	return
+}
+
+func (n *ExpressionNode) RefPath(key string) (RefPath, bool) {
+	ref, ok := n.Refs[key]
+	if !ok {
+		return RefPath{}, false
+	}
+	return ParseRefPath(ref), true
+}
+
+// RefPath is a reference to the result of a declaration (ex: $myinstance)
+// or to one of its nested attributes (ex: $myinstance.privateip)
+type RefPath struct {
+	Name, Attr string
+}
+
+func ParseRefPath(ref string) RefPath {
+	splits := strings.SplitN(ref, ".", 2)
+	path := RefPath{Name: splits[0]}
+	if len(splits) > 1 {
+		path.Attr = splits[1]
+	}
+	return path
+}
+
+func (r RefPath) String() string {
+	if r.Attr == "" {
+		return r.Name
+	}
+	return fmt.Sprintf("%s.%s", r.Name, r.Attr)
+}
+
+var intUnderscoresRegex = regexp.MustCompile(`^(0[oO])?[0-9]+(_[0-9]+)*$`)
+
+// parseInt parses decimal and octal ints (ex: 0o755 or 0755) with optional
+// single '_' separators between digits. Zero prefixed numbers that are not
+// valid octal (ex: 089) are read as decimal.
+func parseInt(text string) (int, error) {
+	if !intUnderscoresRegex.MatchString(text) {
+		return 0, fmt.Errorf("invalid int '%s': misplaced '_' or missing digits", text)
+	}
+	digits := strings.Replace(text, "_", "", -1)
+	if strings.HasPrefix(digits, "0o") || strings.HasPrefix(digits, "0O") {
+		num, err := strconv.ParseInt(digits[2:], 8, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid octal int '%s'", text)
+		}
+		return int(num), nil
+	}
+	if len(digits) > 1 && digits[0] == '0' {
+		if num, err := strconv.ParseInt(digits[1:], 8, 64); err == nil {
+			return int(num), nil
+		}
+	}
+	num, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid int '%s'", text)
+	}
+	return num, nil
+}
+
+// Port is the value of port params (ex: port=443)
+type Port int
+
+// isPortKey reports whether int values of the param key are ports
+func isPortKey(key string) bool {
+	switch key {
+	case "port", "portrange", "fromport", "toport":
+		return true
+	}
+	return false
+}
+
+func parsePort(text string) (Port, error) {
+	num, err := strconv.Atoi(text)
+	if err != nil || num < 0 || num > 65535 {
+		return 0, fmt.Errorf("invalid port '%s': expecting 0-65535", text)
+	}
+	return Port(num), nil
+}
+
+// Range is the value of numeric range params (ex: portrange=80-443,
+// threshold=0.5-1.5, offset=-5-5)
+type Range struct {
+	Lo, Hi float64
+}
+
+func (r Range) String() string {
+	return formatBound(r.Lo) + "-" + formatBound(r.Hi)
+}
+
+func formatBound(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+var rangeRegex = regexp.MustCompile(`^(-?[0-9]+(?:\.[0-9]+)?)-(-?[0-9]+(?:\.[0-9]+)?)$`)
+
+func parseRange(text string) (Range, error) {
+	matches := rangeRegex.FindStringSubmatch(text)
+	if matches == nil {
+		return Range{}, fmt.Errorf("invalid range '%s': expecting low-high", text)
+	}
+	lo, _ := strconv.ParseFloat(matches[1], 64)
+	hi, _ := strconv.ParseFloat(matches[2], 64)
+	if lo > hi {
+		return Range{}, fmt.Errorf("invalid range '%s': low bound greater than high bound", text)
+	}
+	return Range{Lo: lo, Hi: hi}, nil
+}
+
+// Size is the value of params with a size unit (ex: size=100gb),
+// Unit being one of kb, mb, gb, tb in lowercase
+type Size struct {
+	Value int64
+	Unit  string
+}
+
+func (s Size) String() string {
+	return fmt.Sprintf("%d%s", s.Value, s.Unit)
+}
+
+var sizeUnits = map[string]int64{"kb": 1 << 10, "mb": 1 << 20, "gb": 1 << 30, "tb": 1 << 40}
+
+// Bytes converts the size to bytes, units being powers of 1024
+func (s Size) Bytes() int64 {
+	return s.Value * sizeUnits[s.Unit]
+}
+
+// GiB converts the size to gibibytes, rounding up (ex: volume sizes)
+func (s Size) GiB() int64 {
+	bytes := s.Bytes()
+	gib := bytes >> 30
+	if bytes&(1<<30-1) != 0 {
+		gib++
+	}
+	return gib
+}
+
+var sizeRegex = regexp.MustCompile(`^([0-9]+)((?i:kb|mb|gb|tb))$`)
+
+func parseSize(text string) (Size, error) {
+	matches := sizeRegex.FindStringSubmatch(text)
+	if matches == nil {
+		return Size{}, fmt.Errorf("invalid size '%s': expecting digits followed by kb, mb, gb or tb", text)
+	}
+	num, err := strconv.ParseInt(matches[1], 10, 64)
+	unit := strings.ToLower(matches[2])
+	if err != nil || num > math.MaxInt64/sizeUnits[unit] {
+		return Size{}, fmt.Errorf("invalid size '%s': out of range", text)
+	}
+	return Size{Value: num, Unit: unit}, nil
+}
+
+// Color is the value of params given as a hex color (ex: color=#1a2b3c)
+type Color string
+
+// PercentOf is the value of params given as a percentage of the result
+// of a declaration (ex: capacity=50%of$maxsize). Its ref is tracked as
+// the item 0 of the param and Of is set once the ref is resolved,
+// executors computing the value with Compute.
+type PercentOf struct {
+	Percent float64
+	Ref     string
+	Of      interface{}
+}
+
+func (p PercentOf) String() string {
+	return fmt.Sprintf("%s%%of%s", strconv.FormatFloat(p.Percent, 'f', -1, 64), printRef(p.Ref))
+}
+
+// Compute returns the percentage of the resolved ref value
+func (p PercentOf) Compute() (float64, error) {
+	if p.Of == nil {
+		return 0, fmt.Errorf("%s: unresolved ref", p)
+	}
+	of, ok := toFloat(p.Of)
+	if !ok {
+		return 0, fmt.Errorf("%s: '%v' is not a number", p, p.Of)
+	}
+	return of * p.Percent / 100, nil
+}
+
+// Null is the value of params explicitly set to null (ex: description=null),
+// as opposed to params not provided
+var Null = null{}
+
+type null struct{}
+
+func (null) String() string {
+	return "null"
+}
+
+// ARN is an Amazon Resource Name split into its segments
+// (ex: arn:aws:iam::123456789012:role/foo).
+// Region and account are empty for global resources such as S3 buckets
+type ARN struct {
+	Partition, Service, Region, Account, Resource string
+}
+
+func (a ARN) String() string {
+	return strings.Join([]string{"arn", a.Partition, a.Service, a.Region, a.Account, a.Resource}, ":")
+}
+
+func parseARN(text string) (ARN, error) {
+	splits := strings.SplitN(text, ":", 6)
+	if len(splits) != 6 || splits[0] != "arn" {
+		return ARN{}, fmt.Errorf("invalid arn '%s': expecting arn:partition:service:region:account:resource", text)
+	}
+	arn := ARN{Partition: splits[1], Service: splits[2], Region: splits[3], Account: splits[4], Resource: splits[5]}
+	switch {
+	case arn.Partition == "":
+		return ARN{}, fmt.Errorf("invalid arn '%s': empty partition", text)
+	case arn.Service == "":
+		return ARN{}, fmt.Errorf("invalid arn '%s': empty service", text)
+	case arn.Resource == "":
+		return ARN{}, fmt.Errorf("invalid arn '%s': empty resource", text)
+	}
+	return arn, nil
+}
+
+// ResourceID is an AWS resource identifier split into its type
+// prefix and hexadecimal suffix of 8 or 17 digits (ex: vpc-1a2b3c4d)
+type ResourceID struct {
+	Prefix, Suffix string
+}
+
+func (r ResourceID) String() string {
+	return r.Prefix + "-" + r.Suffix
+}
+
+var resourceIDRegex = regexp.MustCompile(`^([a-z]+)-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+// resourceIDPrefixes are the prefixes of the AWS resource ids
+var resourceIDPrefixes = map[string]bool{
+	"acl": true, "aclassoc": true, "ami": true, "cgw": true, "dopt": true,
+	"eigw": true, "eipalloc": true, "eipassoc": true, "eni": true, "i": true,
+	"igw": true, "nat": true, "pcx": true, "r": true, "rtb": true,
+	"rtbassoc": true, "sg": true, "snap": true, "subnet": true, "vgw": true,
+	"vol": true, "vpc": true, "vpce": true, "vpn": true,
+}
+
+func parseResourceID(text string) (ResourceID, error) {
+	matches := resourceIDRegex.FindStringSubmatch(text)
+	if matches == nil || !resourceIDPrefixes[matches[1]] {
+		return ResourceID{}, fmt.Errorf("invalid resource id '%s': expecting a known prefix and 8 or 17 hex digits", text)
+	}
+	return ResourceID{Prefix: matches[1], Suffix: matches[2]}, nil
+}
+
+func extractAttribute(val interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return val, true
+	}
+	v := reflect.ValueOf(val)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		for _, k := range v.MapKeys() {
+			if strings.EqualFold(k.String(), path[0]) {
+				return extractAttribute(v.MapIndex(k).Interface(), path[1:])
+			}
+		}
+	case reflect.Struct:
+		field := v.FieldByNameFunc(func(name string) bool { return strings.EqualFold(name, path[0]) })
+		if field.IsValid() && field.CanInterface() {
+			return extractAttribute(field.Interface(), path[1:])
+		}
+	}
+	return nil, false
+}
+
+func (s *AST) AddAction(text string) {
+	expr := s.currentExpression()
+	if expr == nil {
+		s.addStatement(&ExpressionNode{Action: text})
+	} else {
+		expr.Action = text
+	}
+}
+
+func (s *AST) AddEntity(text string) {
+	expr := s.currentExpression()
+	expr.Entity = text
+}
+
+// PreviousResultRef is the name of the ref to the result
+// of the previous statement (ex: create subnet vpc=$_)
+const PreviousResultRef = "_"
+
+func (s *AST) AddDeclarationIdentifier(text string) {
+	decl := &DeclarationNode{
+		Left:  &IdentifierNode{Ident: unquoteName(text)},
+		Right: &ExpressionNode{},
+	}
+	s.addStatement(decl)
+	if text == PreviousResultRef {
+		s.valueError(fmt.Errorf("cannot declare '%s': reserved for the result of the previous statement", text))
+	}
+}
+
+func (s *AST) AddInclude(text string) {
+	path, err := strconv.Unquote(`"` + text + `"`)
+	if err != nil {
+		panic(fmt.Sprintf("cannot unquote '%s'", text))
+	}
+	s.addStatement(&IncludeNode{Path: path})
+}
+
+func (s *AST) AddVarIdentifier(text string) {
+	ident := unquoteName(text)
+	s.addStatement(&VarNode{Ident: ident})
+	s.varValue = &ExpressionNode{}
+	s.AddParamKey(ident)
+	if ident == PreviousResultRef {
+		s.valueError(fmt.Errorf("cannot declare '%s': reserved for the result of the previous statement", ident))
+	}
+}
+
+// EndVar sets the value of the var being parsed, vars
+// on the line of the previous one adding no blank line
+func (s *AST) EndVar() {
+	st := s.currentStatement
+	node, expr := st.Node.(*VarNode), s.varValue
+	if len(expr.Refs)+len(expr.Aliases)+len(expr.Holes)+len(expr.Envs) > 0 {
+		s.valueError(fmt.Errorf("var '%s': expecting a literal value", node.Ident))
+	}
+	node.Value = expr.Params[node.Ident]
+	if n := len(s.Statements); n > 1 && s.Statements[n-2].LineNumber == st.LineNumber {
+		st.BlankLines = 0
+	}
+	s.varValue = nil
+	s.LineDone()
+}
+
+func (s *AST) AddDeclarationExtraIdentifier(text string) {
+	decl := s.currentStatement.Node.(*DeclarationNode)
+	decl.Extra = append(decl.Extra, &IdentifierNode{Ident: unquoteName(text)})
+}
+
+func (s *AST) NegateGuard() {
+	s.pendingGuard().Not = true
+}
+
+func (s *AST) AddGuardHole(text string) {
+	s.pendingGuard().Hole = text
+}
+
+func (s *AST) AddGuardValue(text string) {
+	s.pendingGuard().Value = text == "true"
+}
+
+func (s *AST) AddGuardOp(text string) {
+	s.pendingGuard().Op = text
+}
+
+func (s *AST) AddGuardOperand(text string) {
+	s.pendingGuard().Operand = parseGuardOperand(text)
+}
+
+// pendingGuard returns the guard of the statement being parsed,
+// the guard actions running before the statement is added
+func (s *AST) pendingGuard() *Guard {
+	if s.guard == nil {
+		s.guard = &Guard{}
+	}
+	return s.guard
+}
+
+// disableDirective is the comment disabling validation checks
+// for the next statement
+const disableDirective = "# awless:disable"
+
+// AddComment records the checks disabled by a directive comment
+// (ex: # awless:disable missing-param) for the next statement
+func (s *AST) AddComment(text string) {
+	if !isDisableDirective(text) {
+		return
+	}
+	text = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(text, "#"), "//"))
+	fields := strings.FieldsFunc(strings.TrimPrefix(text, "awless:disable"), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	s.disabledChecks = append(s.disabledChecks, fields...)
+}
+
+func isDisableDirective(comment string) bool {
+	comment = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(comment, "#"), "//"))
+	return strings.HasPrefix(comment, "awless:disable")
+}
+
+// StartWith starts a with block whose params are collected
+// until the statements of the block are parsed
+func (s *AST) StartWith() {
+	s.with = &ExpressionNode{}
+	s.withStart = len(s.Statements)
+	s.currentStatement = &Statement{Node: s.with}
+	s.paramsCount = 0
+}
+
+// EndWith applies the params of the with block to its statements
+func (s *AST) EndWith() {
+	for _, st := range s.Statements[s.withStart:] {
+		st.expression().inherit(s.with)
+	}
+	s.with = nil
+}
+
+func (s *AST) LineDone() {
+	if st := s.currentStatement; st != nil && st.Guard != nil && st.Guard.Hole != "" {
+		if err := checkHoleName(st.Guard.Hole); err != nil {
+			s.valueError(err)
+		}
+	}
+	if st := s.currentStatement; st != nil && len(s.Statements) > 0 && st == s.Statements[0] {
+		for _, ref := range st.expression().Refs {
+			if ParseRefPath(ref).Name == PreviousResultRef {
+				s.valueError(fmt.Errorf("'$%s' refers to the result of the previous statement: none before the first statement", PreviousResultRef))
+				break
+			}
+		}
+	}
+	s.currentStatement = nil
+	s.currentKey = ""
+}
+
+func (s *AST) AddParamKey(text string) {
+	expr := s.currentExpression()
+	if expr.Params == nil {
+		expr.Refs = make(map[string]string)
+		expr.Params = make(map[string]interface{})
+		expr.Aliases = make(map[string]string)
+		expr.Holes = make(map[string]string)
+	}
+	if expr.hasKey(text) {
+		s.valueError(fmt.Errorf("param '%s' specified more than once", text))
+	}
+	s.currentKey = text
+
+	s.paramsCount++
+	if s.limits != nil && s.limits.MaxParamsPerStatement > 0 && s.paramsCount > s.limits.MaxParamsPerStatement {
+		panic(limitExceeded{ErrMaxParams})
+	}
+}
+
+func (s *AST) AddParamValue(text string) {
+	expr := s.currentExpression()
+	expr.Params[s.currentKey] = text
+}
+
+func (s *AST) AddParamQuotedValue(text string) {
+	expr := s.currentExpression()
+	str, in, err := parseQuoted(text)
+	if err != nil {
+		panic(fmt.Sprintf("cannot unquote '%s'", text))
+	}
+	if in != nil && s.listKey == "" {
+		for i, part := range in {
+			if part.Hole == "" {
+				continue
+			}
+			if err := checkHoleName(part.Hole); err != nil {
+				s.valueError(err)
+				return
+			}
+			key := listItemKey(s.currentKey, i)
+			expr.Holes[key] = part.Hole
+			expr.holeKeys = append(expr.holeKeys, key)
+		}
+		expr.Params[s.currentKey] = in
+		return
+	}
+	expr.Params[s.currentKey] = str
+}
+
+// CascadeModifier asks for the deletion of the dependents of the deleted
+// resource (ex: delete vpc id=$myvpc cascade)
+const CascadeModifier = "cascade"
+
+func (s *AST) AddModifier(text string) {
+	expr := s.currentExpression()
+	if expr.Action != "delete" {
+		s.valueError(fmt.Errorf("modifier '%s' only applies to delete statements", text))
+		return
+	}
+	if expr.Modifiers == nil {
+		expr.Modifiers = make(map[string]bool)
+	}
+	expr.Modifiers[text] = true
+}
+
+func (s *AST) AddFlag(text string) {
+	expr := s.currentExpression()
+	if expr.HasFlag(text) {
+		s.valueError(fmt.Errorf("flag '--%s' specified more than once", text))
+		return
+	}
+	expr.Flags = append(expr.Flags, text)
+}
+
+func (s *AST) AddParamRegexValue(text string) {
+	expr := s.currentExpression()
+	if _, err := regexp.Compile(text); err != nil {
+		s.valueError(fmt.Errorf("param '%s': invalid regex '%s': %s", s.currentKey, text, err))
+		return
+	}
+	if expr.Matches == nil {
+		expr.Matches = make(map[string]bool)
+	}
+	expr.Params[s.currentKey] = text
+	expr.Matches[s.currentKey] = true
+}
+
+func (s *AST) AddParamHeredocValue(text string) {
+	expr := s.currentExpression()
+	expr.Params[s.currentKey] = strings.TrimSuffix(text, "\r")
+}
+
+func (s *AST) AddParamIntValue(text string) {
+	expr := s.currentExpression()
+	num, err := parseInt(text)
+	if err != nil {
+		s.valueError(err)
+		return
+	}
+	if isPortKey(s.currentKey) {
+		port, err := parsePort(strconv.Itoa(num))
+		if err != nil {
+			s.valueError(err)
+			return
+		}
+		expr.Params[s.currentKey] = port
+		return
+	}
+	expr.Params[s.currentKey] = num
+}
+
+func (s *AST) AddParamHexValue(text string) {
+	num, err := strconv.ParseInt(text, 0, 64)
+	if err != nil {
+		s.valueError(err)
+		return
+	}
+	s.AddParamIntValue(strconv.FormatInt(num, 10))
+}
+
+func (s *AST) AddParamSizeValue(text string) {
+	expr := s.currentExpression()
+	size, err := parseSize(text)
+	if err != nil {
+		s.valueError(err)
+		return
+	}
+	expr.Params[s.currentKey] = size
+}
+
+func (s *AST) AddParamPercentOfValue(text string) {
+	expr := s.currentExpression()
+	parts := strings.SplitN(text, "%of$", 2)
+	percent, err := parseFloat(parts[0])
+	if err != nil {
+		s.valueError(err)
+		return
+	}
+	ref := unquoteName(parts[1])
+	expr.Params[s.currentKey] = PercentOf{Percent: percent, Ref: ref}
+	expr.Refs[listItemKey(s.currentKey, 0)] = ref
+}
+
+func (s *AST) AddParamColorValue(text string) {
+	s.currentExpression().Params[s.currentKey] = Color(text)
+}
+
+func (s *AST) AddParamIntRangeValue(text string) {
+	expr := s.currentExpression()
+	r, err := parseRange(text)
+	if err != nil {
+		s.valueError(err)
+		return
+	}
+	if isPortKey(s.currentKey) {
+		for _, bound := range []float64{r.Lo, r.Hi} {
+			if _, err := parsePort(formatBound(bound)); err != nil {
+				s.valueError(err)
+				return
+			}
+		}
+	}
+	expr.Params[s.currentKey] = r
+}
+
+func (s *AST) StartList() {
+	expr := s.currentExpression()
+	s.listKey = s.currentKey
+	expr.Params[s.listKey] = []interface{}{}
+}
+
+// NextListItem makes the following value actions fill the next item of the
+// current list, registering refs, aliases and holes under "key[index]"
+func (s *AST) NextListItem() {
+	expr := s.currentExpression()
+	list := expr.Params[s.listKey].([]interface{})
+	s.currentKey = listItemKey(s.listKey, len(list))
+	expr.Params[s.listKey] = append(list, nil)
+}
+
+func (s *AST) EndList() {
+	s.currentKey, s.listKey = s.listKey, ""
+	s.currentExpression().mergeItems()
+}
+
+func (s *AST) AddParamListValue(text string) {
+	expr := s.currentExpression()
+	expr.Params[s.currentKey] = strings.Split(text, ",")
+}
+
+func (s *AST) AddParamFloatValue(text string) {
+	expr := s.currentExpression()
+	num, err := parseFloat(text)
+	if err != nil {
+		s.valueError(err)
+		return
+	}
+	expr.Params[s.currentKey] = num
+}
+
+func (s *AST) AddParamCidrValue(text string) {
+	expr := s.currentExpression()
+	_, ipnet, err := net.ParseCIDR(text)
+	if err != nil {
+		panic(fmt.Sprintf("cannot convert '%s' to net cidr", text))
+	}
+	expr.Params[s.currentKey] = ipnet.String()
+}
+
+func (s *AST) AddParamIpValue(text string) {
+	expr := s.currentExpression()
+	ip := net.ParseIP(text)
+	if ip == nil {
+		panic(fmt.Sprintf("cannot convert '%s' to net ip", text))
+	}
+	expr.Params[s.currentKey] = ip.String()
+}
+
+func (s *AST) AddParamArnValue(text string) {
+	expr := s.currentExpression()
+	arn, err := parseARN(text)
+	if err != nil {
+		panic(err.Error())
+	}
+	expr.Params[s.currentKey] = arn
+}
+
+func (s *AST) AddParamResourceIdValue(text string) {
+	expr := s.currentExpression()
+	id, err := parseResourceID(text)
+	if err != nil {
+		expr.Params[s.currentKey] = text
+		return
+	}
+	expr.Params[s.currentKey] = id
+}
+
+func (s *AST) AddParamNullValue() {
+	expr := s.currentExpression()
+	expr.Params[s.currentKey] = Null
+}
+
+func (s *AST) AddParamRefValue(text string) {
+	expr := s.currentExpression()
+	expr.Refs[s.currentKey] = unquoteName(text)
+}
+
+func (s *AST) AddParamAliasValue(text string) {
+	expr := s.currentExpression()
+	expr.Aliases[s.currentKey] = unquoteName(text)
+}
+
+func (s *AST) AddParamEnvValue(text string) {
+	expr := s.currentExpression()
+	if expr.Envs == nil {
+		expr.Envs = make(map[string]string)
+	}
+	expr.Envs[s.currentKey] = text
+}
+
+func (s *AST) AddParamHoleValue(text string) {
+	expr := s.currentExpression()
+	name := unquoteName(text)
+	if name == text {
+		if err := checkHoleName(text); err != nil {
+			s.valueError(err)
+			return
+		}
+	} else if name == "" {
+		s.valueError(errors.New("empty hole name"))
+		return
+	}
+	expr.Holes[s.currentKey] = name
+	expr.holeKeys = append(expr.holeKeys, s.currentKey)
+}
+
+// safeHoleName is the pattern hole names must match, so that tools can
+// safely pass them around (ex: to prompts or shell commands)
+var safeHoleName = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]*$`)
+
+func checkHoleName(name string) error {
+	if !safeHoleName.MatchString(name) {
+		return fmt.Errorf("invalid hole name '%s': expecting letters, digits, '_', '.' or '-', not starting with '.' or '-'", name)
+	}
+	return nil
+}
+
+func (s *AST) AddParamHoleType(text string) {
+	expr := s.currentExpression()
+	switch text {
+	case "string", "int", "cidr", "ip":
+	default:
+		s.valueError(fmt.Errorf("hole '%s': unknown type '%s', expecting string, int, cidr or ip", expr.Holes[s.currentKey], text))
+		return
+	}
+	if expr.HoleTypes == nil {
+		expr.HoleTypes = make(map[string]string)
+	}
+	expr.HoleTypes[s.currentKey] = text
+}
+
+func (s *AST) currentExpression() *ExpressionNode {
+	st := s.currentStatement
+	if st == nil {
+		return nil
+	}
+
+	switch st.Node.(type) {
+	case *ExpressionNode:
+		return st.Node.(*ExpressionNode)
+	case *DeclarationNode:
+		return st.Node.(*DeclarationNode).Right
+	case *VarNode:
+		return s.varValue
+	default:
+		panic("last expression: unexpected node type")
+	}
+}
+
+// FillHoles fills the holes of all statements and reports the names
+// of the holes filled, the names of the holes remaining unfilled
+// and the keys of the given fills that did not match any hole.
+// It returns the first error of the fills not matching their hole type.
+func (a *AST) FillHoles(fills map[string]interface{}) (filled, unfilled, unused []string, err error) {
+	used := make(map[string]bool)
+	filledSet := make(map[string]bool)
+	unfilledSet := make(map[string]bool)
+
+	for _, expr := range a.expressionNodes() {
+		for _, hole := range expr.Holes {
+			used[hole] = true
+		}
+		if _, perr := expr.ProcessHoles(fills); perr != nil && err == nil {
+			err = fmt.Errorf("%s %s: %s", expr.Action, expr.Entity, perr)
+		}
+		for _, hole := range expr.Holes {
+			unfilledSet[hole] = true
+		}
+	}
+
+	for hole := range used {
+		if _, ok := fills[hole]; ok && !unfilledSet[hole] {
+			filledSet[hole] = true
+		}
+	}
+	for k := range fills {
+		if !used[k] {
+			unused = append(unused, k)
+		}
+	}
+
+	filled, unfilled = sortedKeys(filledSet), sortedKeys(unfilledSet)
+	sort.Strings(unused)
+	return
+}
+
+// WithHoles returns a clone of the template with its holes filled,
+// leaving the template itself untouched
+func (a *AST) WithHoles(fills map[string]interface{}) (*AST, error) {
+	clone := a.Clone()
+	for _, expr := range clone.expressionNodes() {
+		if _, err := expr.ProcessHoles(fills); err != nil {
+			return nil, err
+		}
+	}
+	return clone, nil
+}
+
+// ResolveEnv substitutes all environment variable values of the template
+// using lookup (ex: os.LookupEnv). It errors with the names of all
+// the variables not found.
+func (a *AST) ResolveEnv(lookup func(string) (string, bool)) error {
+	missing := make(map[string]bool)
+	for _, expr := range a.expressionNodes() {
+		for key, name := range expr.Envs {
+			val, ok := lookup(name)
+			if !ok {
+				missing[name] = true
+				continue
+			}
+			if expr.Params == nil {
+				expr.Params = make(map[string]interface{})
+			}
+			expr.Params[key] = val
+			delete(expr.Raw, key)
+			delete(expr.Envs, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing environment variables: %s", strings.Join(sortedKeys(missing), ", "))
+	}
+	return nil
+}
+
+// Aliases returns every alias used in the template with the places
+// it is used as normalized param keys (ex: "subnet.vpc"), in statement order
+func (a *AST) Aliases() map[string][]string {
+	aliases := make(map[string][]string)
+	for _, expr := range a.expressionNodes() {
+		var keys []string
+		for k := range expr.Aliases {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			alias := expr.Aliases[k]
+			aliases[alias] = append(aliases[alias], fmt.Sprintf("%s.%s", expr.Entity, k))
+		}
+	}
+	return aliases
+}
+
+// Entities returns the sorted distinct entities of the expressions
+// and declarations of the template (ex: to check the services it uses)
+func (a *AST) Entities() []string {
+	entities := make(map[string]bool)
+	for _, expr := range a.expressionNodes() {
+		entities[expr.Entity] = true
+	}
+	return sortedKeys(entities)
+}
+
+// Summary returns the number of statements of the template per action and
+// entity (ex: "create instance": 3) to describe the changes it makes
+func (a *AST) Summary() map[string]int {
+	summary := make(map[string]int)
+	for _, expr := range a.expressionNodes() {
+		summary[expr.Action+" "+expr.Entity]++
+	}
+	return summary
+}
+
+// ResolveParamRefs substitutes the refs to params of declarations
+// (ex: $myvpc.params.cidr) with the literal value of the param. Only
+// declarations made above can be referenced, which also rules out cycles.
+// Errors are set on the offending statements and the first one is returned.
+func (a *AST) ResolveParamRefs() (err error) {
+	declared := make(map[string]*ExpressionNode)
+	for i, st := range a.Statements {
+		var expr *ExpressionNode
+		switch n := st.Node.(type) {
+		case *ExpressionNode:
+			expr = n
+		case *DeclarationNode:
+			expr = n.Right
+		default:
+			continue
+		}
+
+		var keys []string
+		for k := range expr.Refs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			path := ParseRefPath(expr.Refs[k])
+			if !strings.HasPrefix(path.Attr, "params.") {
+				continue
+			}
+			param := strings.TrimPrefix(path.Attr, "params.")
+
+			var rerr error
+			var val interface{}
+			src, found := declared[path.Name]
+			if found {
+				val, found = src.Params[param]
+			}
+			switch {
+			case src == nil && a.declaredFrom(i, path.Name):
+				rerr = fmt.Errorf("forward reference to '%s'", path.Name)
+			case src == nil:
+				rerr = fmt.Errorf("no declaration '%s'", path.Name)
+			case !found:
+				rerr = fmt.Errorf("'%s' has no literal param '%s'", path.Name, param)
+			}
+			if rerr != nil {
+				rerr = fmt.Errorf("%s %s: ref '$%s': %s", expr.Action, expr.Entity, path, rerr)
+				if st.Err == nil {
+					st.Err = rerr
+				}
+				if err == nil {
+					err = rerr
+				}
+				continue
+			}
+			if expr.Params == nil {
+				expr.Params = make(map[string]interface{})
+			}
+			expr.Params[k] = cloneValue(val)
+			delete(expr.Raw, k)
+			delete(expr.Refs, k)
+		}
+		expr.mergeItems()
+
+		if decl, ok := st.Node.(*DeclarationNode); ok {
+			for _, ident := range decl.Idents() {
+				declared[ident.Ident] = expr
+			}
+		}
+	}
+	return
+}
+
+// declaredFrom reports whether the name is declared by the statement
+// at index or by one of the following statements
+func (a *AST) declaredFrom(index int, name string) bool {
+	for _, st := range a.Statements[index:] {
+		if decl, ok := st.Node.(*DeclarationNode); ok {
+			for _, ident := range decl.Idents() {
+				if ident.Ident == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ResolveAliases substitutes each alias value with the identifier returned by
+// resolve, given the entity of the statement. Resolution errors are set on
+// the offending statements and the first one is returned.
+func (a *AST) ResolveAliases(resolve func(entity, alias string) (string, error)) (err error) {
+	for _, st := range a.Statements {
+		var expr *ExpressionNode
+		switch n := st.Node.(type) {
+		case *ExpressionNode:
+			expr = n
+		case *DeclarationNode:
+			expr = n.Right
+		default:
+			continue
+		}
+
+		var keys []string
+		for k := range expr.Aliases {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			id, rerr := resolve(expr.Entity, expr.Aliases[k])
+			if rerr != nil {
+				rerr = fmt.Errorf("%s %s: alias '%s': %s", expr.Action, expr.Entity, expr.Aliases[k], rerr)
+				if st.Err == nil {
+					st.Err = rerr
+				}
+				if err == nil {
+					err = rerr
+				}
+				continue
+			}
+			if expr.Params == nil {
+				expr.Params = make(map[string]interface{})
+			}
+			expr.Params[k] = id
+			delete(expr.Raw, k)
+			delete(expr.Aliases, k)
+		}
+		expr.mergeItems()
+	}
+	return
+}
+
+// ReplaceParam sets to new the params of key whose value equals old,
+// comparing typed values (ex: the int 80 does not match the string "80").
+// It returns the number of params replaced.
+func (a *AST) ReplaceParam(key string, old, new interface{}) (count int) {
+	for _, expr := range a.expressionNodes() {
+		if v, ok := expr.Params[key]; ok && reflect.DeepEqual(v, old) {
+			expr.Params[key] = new
+			delete(expr.Raw, key)
+			count++
+		}
+	}
+	return
+}
+
+// Rename renames a declaration or var and updates all references and holes
+// pointing to it throughout the template
+func (a *AST) Rename(old, new string) error {
+	var decl *Statement
+	for _, st := range a.Statements {
+		for _, ident := range st.declaredIdents() {
+			switch ident {
+			case new:
+				return fmt.Errorf("rename: '%s' already declared", new)
+			case old:
+				decl = st
+			}
+		}
+	}
+	if decl == nil {
+		return fmt.Errorf("rename: no declaration '%s'", old)
+	}
+
+	switch n := decl.Node.(type) {
+	case *DeclarationNode:
+		for _, ident := range n.Idents() {
+			if ident.Ident == old {
+				ident.Ident = new
+			}
+		}
+	case *VarNode:
+		n.Ident = new
+	}
+	for _, expr := range a.expressionNodes() {
+		for k, ref := range expr.Refs {
+			if path := ParseRefPath(ref); path.Name == old {
+				path.Name = new
+				expr.Refs[k] = path.String()
+				if p, ok := expr.percentOfRef(k); ok {
+					p.Ref = path.String()
+					expr.Params[strings.TrimSuffix(k, "[0]")] = p
+				}
+			}
+		}
+		for k, hole := range expr.Holes {
+			if hole == old {
+				expr.Holes[k] = new
+			}
+		}
+	}
+	return nil
+}
+
+// ValidationError locates a problem found validating a template
+type ValidationError struct {
+	Statement *Statement
+	Line      int
+	Kind      string
+	Message   string
+	// Warning is set for ambiguities that do not prevent running the template
+	Warning bool
+}
+
+func NewValidationError(st *Statement, kind, format string, a ...interface{}) *ValidationError {
+	return &ValidationError{Statement: st, Line: st.LineNumber, Kind: kind, Message: fmt.Sprintf(format, a...)}
+}
+
+func NewValidationWarning(st *Statement, kind, format string, a ...interface{}) *ValidationError {
+	warn := NewValidationError(st, kind, format, a...)
+	warn.Warning = true
+	return warn
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+var (
+	statementValidatorsMu sync.Mutex
+	statementValidators   []func(*Statement) []error
+)
+
+// RegisterStatementValidator adds a validator run by Validate on each
+// statement to enforce custom rules (ex: forbidden regions). Validators
+// should return ValidationErrors to locate the statement.
+func RegisterStatementValidator(fn func(*Statement) []error) {
+	statementValidatorsMu.Lock()
+	defer statementValidatorsMu.Unlock()
+	statementValidators = append(statementValidators, fn)
+}
+
+// Validate checks the template is consistent and returns all the problems
+// found as ValidationError. Currently it reports identifiers declared more than
+// once and, as warnings, holes named after a declaration (ex: region = create ...
+// and {region}) as it is unclear whether the hole is meant to be prompted for.
+// Errors of the registered statement validators follow.
+func (a *AST) Validate() (errs []error) {
+	declared := make(map[string]*Statement)
+	for _, st := range a.Statements {
+		for _, ident := range st.declaredIdents() {
+			if first, ok := declared[ident]; ok {
+				errs = append(errs, NewValidationError(st, "duplicate-identifier", "duplicate identifier '%s': declared line %d and line %d", ident, first.LineNumber, st.LineNumber))
+				continue
+			}
+			declared[ident] = st
+		}
+	}
+	for _, hole := range a.OrderedHoles() {
+		if decl, ok := declared[hole.Name]; ok {
+			errs = append(errs, NewValidationWarning(hole.Statement, "shadowed-hole", "hole '%s' line %d has the name of the identifier declared line %d", hole.Name, hole.Line, decl.LineNumber))
+		}
+	}
+	statementValidatorsMu.Lock()
+	validators := statementValidators
+	statementValidatorsMu.Unlock()
+	for _, st := range a.Statements {
+		for _, validate := range validators {
+			errs = append(errs, validate(st)...)
+		}
+	}
+	return WithoutDisabled(errs)
+}
+
+// WithoutDisabled returns the errors except the ValidationErrors of a check
+// disabled on their statement
+func WithoutDisabled(errs []error) (kept []error) {
+	for _, err := range errs {
+		if verr, ok := err.(*ValidationError); ok && verr.Statement != nil && verr.Statement.Disables(verr.Kind) {
+			continue
+		}
+		kept = append(kept, err)
+	}
+	return
+}
+
+// HoleRef locates the first occurrence of a hole in a template.
+// Default is empty as holes have no default value syntax yet
+type HoleRef struct {
+	Name      string
+	Line      int
+	Statement *Statement
+	Type      string
+	Default   string
+}
+
+// OrderedHoles returns the holes of the template in source order
+// (ex: to prompt for them top to bottom), once per hole name
+func (a *AST) OrderedHoles() (holes []HoleRef) {
+	seen := make(map[string]bool)
+	add := func(st *Statement, name, typ string) {
+		if !seen[name] {
+			seen[name] = true
+			holes = append(holes, HoleRef{Name: name, Line: st.LineNumber, Statement: st, Type: typ})
+		}
+	}
+	for _, st := range a.Statements {
+		if st.Guard != nil && st.Guard.Hole != "" {
+			add(st, st.Guard.Hole, "")
+		}
+		switch st.Node.(type) {
+		case *ExpressionNode, *DeclarationNode:
+		default:
+			continue
+		}
+		expr := st.expression()
+		for _, key := range expr.orderedHoleKeys() {
+			add(st, expr.Holes[key], expr.HoleTypes[key])
+		}
+	}
+	return
+}
+
+// orderedHoleKeys returns the hole keys in source order, followed by
+// the ones added after parsing sorted by key
+func (n *ExpressionNode) orderedHoleKeys() (keys []string) {
+	done := make(map[string]bool)
+	for _, key := range n.holeKeys {
+		if _, ok := n.Holes[key]; ok && !done[key] {
+			done[key] = true
+			keys = append(keys, key)
+		}
+	}
+	var others []string
+	for key := range n.Holes {
+		if !done[key] {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+	return append(keys, others...)
+}
+
+func (a *AST) expressionNodes() (nodes []*ExpressionNode) {
+	for _, st := range a.Statements {
+		switch n := st.Node.(type) {
+		case *ExpressionNode:
+			nodes = append(nodes, n)
+		case *DeclarationNode:
+			nodes = append(nodes, n.Right)
+		}
+	}
+	return
+}
+
+func sortedKeys(m map[string]bool) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// Append adds a statement built in code at the end of the template.
+// It errors, adding nothing, when the statement declares an identifier
+// already declared in the template or more than once itself.
+func (a *AST) Append(st *Statement) error {
+	if st == nil || st.Node == nil {
+		return errors.New("append: empty statement")
+	}
+	declared := make(map[string]bool)
+	for _, existing := range a.Statements {
+		for _, ident := range existing.declaredIdents() {
+			declared[ident] = true
+		}
+	}
+	for _, ident := range st.declaredIdents() {
+		if declared[ident] {
+			return fmt.Errorf("append: identifier '%s' already declared", ident)
+		}
+		declared[ident] = true
+	}
+	a.Statements = append(a.Statements, st)
+	return nil
+}
+
+func (a *AST) Clone() *AST {
+	clone := &AST{}
+	for _, stat := range a.Statements {
+		clone.Statements = append(clone.Statements, stat.clone())
 	}
 	return clone
 }
 
+func (s *AST) valueError(err error) {
+	var line int
+	if s.currentStatement != nil {
+		line = s.currentStatement.LineNumber
+	}
+	s.valueErrs = append(s.valueErrs, &ParseError{Line: line, msg: fmt.Sprintf("line %d: %s", line, err)})
+}
+
 func (s *AST) addStatement(n Node) {
-	stat := &Statement{Node: n}
+	stat := &Statement{Node: n, Guard: s.guard, DisabledChecks: s.disabledChecks}
+	s.guard, s.disabledChecks = nil, nil
 	s.currentStatement = stat
 	s.Statements = append(s.Statements, stat)
+
+	s.paramsCount = 0
+	if s.limits != nil && s.limits.MaxStatements > 0 && len(s.Statements) > s.limits.MaxStatements {
+		panic(limitExceeded{ErrMaxStatements})
+	}
 }
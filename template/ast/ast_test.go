@@ -17,7 +17,10 @@ limitations under the License.
 package ast
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -62,6 +65,39 @@ func TestCloneAST(t *testing.T) {
 	}
 }
 
+func TestCloneDeepCopiesValues(t *testing.T) {
+	tree := mustParse(t, "mygroup = create securitygroup cidr=10.0.0.0/8,10.1.0.0/8")
+	tree.Statements[0].Params()["tags"] = map[string]interface{}{"env": []string{"prod"}}
+
+	clone := tree.Clone()
+	clone.Statements[0].Params()["cidr"].([]string)[0] = "192.168.0.0/16"
+	clone.Statements[0].Params()["tags"].(map[string]interface{})["env"].([]string)[0] = "dev"
+
+	if got, want := tree.Statements[0].Params()["cidr"], []string{"10.0.0.0/8", "10.1.0.0/8"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := tree.Statements[0].Params()["tags"], map[string]interface{}{"env": []string{"prod"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestCloneDeepCopiesIdentifierValues(t *testing.T) {
+	tree := mustParse(t, "subnets = create subnet\ntags = create tag")
+	tree.Statements[0].Node.(*DeclarationNode).Left.Val = []string{"subnet-1", "subnet-2"}
+	tree.Statements[1].Node.(*DeclarationNode).Left.Val = map[string]interface{}{"env": "prod"}
+
+	clone := tree.Clone()
+	clone.Statements[0].Node.(*DeclarationNode).Left.Val.([]string)[0] = "subnet-3"
+	clone.Statements[1].Node.(*DeclarationNode).Left.Val.(map[string]interface{})["env"] = "dev"
+
+	if got, want := tree.Statements[0].Node.(*DeclarationNode).Left.Val, []string{"subnet-1", "subnet-2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := tree.Statements[1].Node.(*DeclarationNode).Left.Val, map[string]interface{}{"env": "prod"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
 func TestGetStatementAttributes(t *testing.T) {
 	params := map[string]interface{}{"count": 1}
 	st := &Statement{Node: &DeclarationNode{
@@ -92,3 +128,1692 @@ func TestGetStatementAttributes(t *testing.T) {
 		t.Fatalf("got %s, want %s", got, want)
 	}
 }
+
+func TestProcessRefsWithAttributes(t *testing.T) {
+	type instance struct {
+		PrivateIP string
+		Network   map[string]interface{}
+	}
+	fills := map[string]interface{}{
+		"myvpc":      "vpc-12345",
+		"myinstance": &instance{PrivateIP: "10.0.0.12", Network: map[string]interface{}{"subnet": "sub-12345"}},
+	}
+
+	expr := &ExpressionNode{
+		Action: "create", Entity: "instance",
+		Refs: map[string]string{"vpc": "myvpc", "ip": "myinstance.privateip", "subnet": "myinstance.network.subnet", "missing": "myinstance.none"},
+	}
+
+	if got, want := ParseRefPath("myvpc"), (RefPath{Name: "myvpc"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := ParseRefPath("myinstance.network.subnet"), (RefPath{Name: "myinstance", Attr: "network.subnet"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if path, _ := expr.RefPath("ip"); path != (RefPath{Name: "myinstance", Attr: "privateip"}) {
+		t.Fatalf("got %#v", path)
+	}
+
+	expr.ProcessRefs(fills)
+
+	expected := map[string]interface{}{"vpc": "vpc-12345", "ip": "10.0.0.12", "subnet": "sub-12345"}
+	if got, want := expr.Params, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Refs, map[string]string{"missing": "myinstance.none"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestProcessTypedHoles(t *testing.T) {
+	tcases := []struct {
+		typ    string
+		fill   interface{}
+		expect interface{}
+		err    bool
+	}{
+		{typ: "int", fill: 5, expect: 5},
+		{typ: "int", fill: "5", expect: 5},
+		{typ: "int", fill: "abc", err: true},
+		{typ: "cidr", fill: "10.0.0.0/16", expect: "10.0.0.0/16"},
+		{typ: "cidr", fill: "10.0.0.0", err: true},
+		{typ: "ip", fill: "127.0.0.1", expect: "127.0.0.1"},
+		{typ: "ip", fill: "abc", err: true},
+		{typ: "string", fill: "abc", expect: "abc"},
+		{typ: "unknown", fill: "abc", err: true},
+	}
+
+	for _, tcase := range tcases {
+		expr := &ExpressionNode{
+			Holes:     map[string]string{"x": "x"},
+			HoleTypes: map[string]string{"x": tcase.typ},
+		}
+		processed, err := expr.ProcessHoles(map[string]interface{}{"x": tcase.fill})
+		if tcase.err {
+			if err == nil {
+				t.Fatalf("%s, %v: expected error got none", tcase.typ, tcase.fill)
+			}
+			if got, want := len(expr.Holes), 1; got != want {
+				t.Fatalf("%s, %v: got %d, want %d", tcase.typ, tcase.fill, got, want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s, %v: %s", tcase.typ, tcase.fill, err)
+		}
+		if got, want := processed["x"], tcase.expect; got != want {
+			t.Fatalf("%s: got %#v, want %#v", tcase.typ, got, want)
+		}
+		if got, want := expr.Params["x"], tcase.expect; got != want {
+			t.Fatalf("%s: got %#v, want %#v", tcase.typ, got, want)
+		}
+	}
+}
+
+func TestParseHoleTypes(t *testing.T) {
+	tree := mustParse(t, "create instance count={instance.count:int} subnet={instance.subnet:string}")
+	if got, want := tree.Statements[0].Node.(*ExpressionNode).HoleTypes, map[string]string{"count": "int", "subnet": "string"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	_, err := Parse("create vpc cidr={vpc.cidr:foo}")
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	if got, want := err.Error(), "hole 'vpc.cidr': unknown type 'foo', expecting string, int, cidr or ip"; !strings.Contains(got, want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestFillHoles(t *testing.T) {
+	tree := &AST{}
+	first := &ExpressionNode{
+		Action: "create", Entity: "instance",
+		Holes:     map[string]string{"type": "instance.type", "count": "instance.count", "subnet": "instance.subnet"},
+		HoleTypes: map[string]string{"count": "int"},
+	}
+	second := &ExpressionNode{
+		Action: "create", Entity: "subnet",
+		Holes: map[string]string{"cidr": "subnet.cidr", "vpc": "instance.vpc"},
+	}
+	tree.Statements = append(tree.Statements, &Statement{Node: first}, &Statement{Node: &DeclarationNode{
+		Left: &IdentifierNode{Ident: "mysubnet"}, Right: second,
+	}})
+
+	filled, unfilled, unused, err := tree.FillHoles(map[string]interface{}{
+		"instance.type":  "t2.micro",
+		"instance.count": "abc",
+		"subnet.cidr":    "10.0.0.0/24",
+		"instance.typo":  "t2.nano",
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "instance.count") {
+		t.Fatalf("got %v, want error on instance.count", err)
+	}
+	if got, want := filled, []string{"instance.type", "subnet.cidr"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("filled: got %v, want %v", got, want)
+	}
+	if got, want := unfilled, []string{"instance.count", "instance.subnet", "instance.vpc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unfilled: got %v, want %v", got, want)
+	}
+	if got, want := unused, []string{"instance.typo"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unused: got %v, want %v", got, want)
+	}
+	if got, want := first.Params, map[string]interface{}{"type": "t2.micro"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := second.Params, map[string]interface{}{"cidr": "10.0.0.0/24"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestWithHoles(t *testing.T) {
+	base := mustParse(t, "create instance type={instance.type} count={instance.count:int}\ncreate subnet cidr={subnet.cidr}")
+
+	first, err := base.WithHoles(map[string]interface{}{"instance.type": "t2.micro", "instance.count": "1", "subnet.cidr": "10.0.0.0/24"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := base.WithHoles(map[string]interface{}{"instance.type": "t2.nano", "instance.count": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := first.Canonical(), "create instance count=1 type=t2.micro\ncreate subnet cidr=10.0.0.0/24"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := second.Canonical(), "create instance count=2 type=t2.nano\ncreate subnet cidr={subnet.cidr}"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := base.Canonical(), "create instance count={instance.count:int} type={instance.type}\ncreate subnet cidr={subnet.cidr}"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err := base.WithHoles(map[string]interface{}{"instance.count": "abc"}); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestRenameDeclaration(t *testing.T) {
+	newTree := func() *AST {
+		tree := &AST{}
+		tree.Statements = append(tree.Statements, &Statement{Node: &DeclarationNode{
+			Left:  &IdentifierNode{Ident: "myvpc"},
+			Right: &ExpressionNode{Action: "create", Entity: "vpc"},
+		}}, &Statement{Node: &DeclarationNode{
+			Left: &IdentifierNode{Ident: "mysubnet"},
+			Right: &ExpressionNode{
+				Action: "create", Entity: "subnet",
+				Refs: map[string]string{"vpc": "myvpc"},
+			},
+		}}, &Statement{Node: &ExpressionNode{
+			Action: "create", Entity: "instance",
+			Refs:  map[string]string{"subnet": "mysubnet", "vpc": "myvpc.id", "other": "myvpcother"},
+			Holes: map[string]string{"network": "myvpc"},
+		}})
+		return tree
+	}
+
+	tree := newTree()
+	if err := tree.Rename("myvpc", "mainvpc"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.Statements[0].Node.(*DeclarationNode).Left.Ident, "mainvpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[1].Node.(*DeclarationNode).Right.Refs, map[string]string{"vpc": "mainvpc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	expr := tree.Statements[2].Node.(*ExpressionNode)
+	if got, want := expr.Refs, map[string]string{"subnet": "mysubnet", "vpc": "mainvpc.id", "other": "myvpcother"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := expr.Holes, map[string]string{"network": "mainvpc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	tree = newTree()
+	if err := tree.Rename("myvpc", "mysubnet"); err == nil {
+		t.Fatal("expected error got none")
+	}
+	if got, want := tree.Statements[0].Node.(*DeclarationNode).Left.Ident, "myvpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if err := tree.Rename("unknown", "other"); err == nil {
+		t.Fatal("expected error got none")
+	}
+
+	tree = mustParse(t, "var region=us-east-1\nmyvpc = create vpc region=$region")
+	if err := tree.Rename("region", "reg"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.String(), "var reg=us-east-1\nmyvpc = create vpc region=$reg"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if err := tree.Rename("myvpc", "reg"); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestParseGrowsTokensBuffer(t *testing.T) {
+	var lines []string
+	for i := 0; i < 200; i++ {
+		lines = append(lines, fmt.Sprintf("create vpc cidr=10.0.%d.0/24 count=%d", i, i))
+	}
+
+	p := mustParsePeg(t, strings.Join(lines, "\n"))
+	p.Execute()
+	if got, want := len(p.Statements), 200; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := p.Statements[199].Params(), map[string]interface{}{"cidr": "10.0.199.0/24", "count": 199}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	p.AST = &AST{}
+	p.Buffer = "create subnet vpc=$myvpc"
+	p.Reset()
+	if err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	p.Execute()
+	if got, want := len(p.Statements), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := p.Statements[0].String(), "create subnet vpc=$myvpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func BenchmarkParseOneLine(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse("create vpc cidr=10.0.0.0/24"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestQuotedValuesRoundTrip(t *testing.T) {
+	tcases := []struct {
+		input, expected string
+	}{
+		{input: `create policy document='{"a": "b=c"}'`, expected: `create policy document='{"a": "b=c"}'`},
+		{input: `create policy document="{\"a\": \"b=c\"}"`, expected: `create policy document='{"a": "b=c"}'`},
+		{input: `create tags value="it's"`, expected: `create tags value="it's"`},
+		{input: `create tags value="it's \"quoted\""`, expected: `create tags value="it's \"quoted\""`},
+		{input: `create tags value='simple'`, expected: `create tags value=simple`},
+		{input: `create instance port=80`, expected: `create instance port=80`},
+		{input: `create instance name='80'`, expected: `create instance name='80'`},
+		{input: `create instance name="80"`, expected: `create instance name='80'`},
+		{input: `create instance range=80-90`, expected: `create instance range=80-90`},
+		{input: `create instance name='null'`, expected: `create instance name='null'`},
+		{input: `create instance name=nullable`, expected: `create instance name=nullable`},
+		{input: `create instance name=''`, expected: `create instance name=''`},
+		{input: `attach policy name='arn:aws:iam::aws:policy/any'`, expected: `attach policy name='arn:aws:iam::aws:policy/any'`},
+		{input: `create instance name='0x1F'`, expected: `create instance name='0x1F'`},
+		{input: `create instance name='0o755'`, expected: `create instance name='0o755'`},
+		{input: `create instance name='1_000'`, expected: `create instance name='1_000'`},
+		{input: `create instance name='80abc'`, expected: `create instance name='80abc'`},
+		{input: `create instance name='vpc-1a2b3c4d'`, expected: `create instance name='vpc-1a2b3c4d'`},
+		{input: `create instance name='i-0123456789abcdef0'`, expected: `create instance name='i-0123456789abcdef0'`},
+		{input: `create instance name='vpc-123'`, expected: `create instance name=vpc-123`},
+		{input: `create instance name='web-1a2b3c4d'`, expected: `create instance name=web-1a2b3c4d`},
+		{input: `create instance name='10.0.0.1/8'`, expected: `create instance name='10.0.0.1/8'`},
+		{input: `create instance name='10.0.0.0/8'`, expected: `create instance name=10.0.0.0/8`},
+		{input: `create instance name='-5-5'`, expected: `create instance name='-5-5'`},
+	}
+
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.input, err)
+		}
+		if got, want := tree.String(), tcase.expected; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		reparsed, err := Parse(tree.String())
+		if err != nil {
+			t.Fatalf("%s: %s", tree.String(), err)
+		}
+		if !reparsed.Equal(tree) {
+			t.Fatalf("%s: round trip failed, got %s", tcase.input, reparsed)
+		}
+	}
+}
+
+func TestResolveEnv(t *testing.T) {
+	env := map[string]string{"DEPLOY_KEY": "mykey"}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	tree, err := Parse("create instance keypair=${ENV:DEPLOY_KEY} name=any")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.Statements[0].Node.(*ExpressionNode).Envs, map[string]string{"keypair": "DEPLOY_KEY"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if err := tree.ResolveEnv(lookup); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.Statements[0].Params(), map[string]interface{}{"keypair": "mykey", "name": "any"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := len(tree.Statements[0].Node.(*ExpressionNode).Envs), 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	tree, err = Parse("create instance keypair=${ENV:DEPLOY_KEY} image=${ENV:IMAGE}\ncreate subnet zone=${ENV:ZONE} vpc=${ENV:IMAGE}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tree.ResolveEnv(lookup)
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	if got, want := err.Error(), "missing environment variables: IMAGE, ZONE"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestArnValues(t *testing.T) {
+	tree := mustParse(t, "attach policy arn=arn:aws:iam::123456789012:role/foo\ncreate bucket arn=arn:aws:s3:::my-bucket/key\ncreate instance name=arn:invalid")
+
+	iamArn, ok := tree.Statements[0].Params()["arn"].(ARN)
+	if !ok {
+		t.Fatalf("got %T, want ARN", tree.Statements[0].Params()["arn"])
+	}
+	if got, want := iamArn, (ARN{Partition: "aws", Service: "iam", Account: "123456789012", Resource: "role/foo"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	s3Arn, ok := tree.Statements[1].Params()["arn"].(ARN)
+	if !ok {
+		t.Fatalf("got %T, want ARN", tree.Statements[1].Params()["arn"])
+	}
+	if got, want := s3Arn, (ARN{Partition: "aws", Service: "s3", Resource: "my-bucket/key"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	if got, want := tree.Statements[2].Params()["name"], "arn:invalid"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	if got, want := tree.String(), "attach policy arn=arn:aws:iam::123456789012:role/foo\ncreate bucket arn=arn:aws:s3:::my-bucket/key\ncreate instance name=arn:invalid"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	for _, malformed := range []string{"arn:aws:iam", "arn:aws::::role/foo", "arn:aws:iam:::", "nra:aws:iam:::role/foo"} {
+		if _, err := parseARN(malformed); err == nil {
+			t.Fatalf("%s: expected error got none", malformed)
+		}
+	}
+}
+
+func TestCollectAliases(t *testing.T) {
+	tree := mustParse(t, "mysubnet = create subnet vpc=@my-vpc\ncreate securitygroup vpc=@my-vpc name=@my-group\ncreate instance name=test")
+
+	aliases := tree.Aliases()
+	if got, want := len(aliases), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := aliases["my-vpc"], []string{"subnet.vpc", "securitygroup.vpc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := aliases["my-group"], []string{"securitygroup.name"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestResolveAliases(t *testing.T) {
+	resolve := func(entity, alias string) (string, error) {
+		switch {
+		case entity == "subnet" && alias == "my-vpc":
+			return "vpc-1234", nil
+		case entity == "instance" && alias == "my-subnet":
+			return "subnet-5678", nil
+		}
+		return "", fmt.Errorf("not found")
+	}
+
+	t.Run("resolved", func(t *testing.T) {
+		tree := mustParse(t, "mysubnet = create subnet vpc=@my-vpc\ncreate instance subnet=@my-subnet")
+		if err := tree.ResolveAliases(resolve); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := tree.String(), "mysubnet = create subnet vpc=vpc-1234\ncreate instance subnet=subnet-5678"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		for _, st := range tree.Statements {
+			if st.Err != nil {
+				t.Fatal(st.Err)
+			}
+		}
+	})
+
+	t.Run("resolver error", func(t *testing.T) {
+		tree := mustParse(t, "create subnet vpc=@my-vpc\ncreate instance subnet=@unknown")
+		err := tree.ResolveAliases(resolve)
+		if err == nil {
+			t.Fatal("expected error got none")
+		}
+		if tree.Statements[0].Err != nil {
+			t.Fatal(tree.Statements[0].Err)
+		}
+		if got, want := tree.Statements[1].Err, err; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := err.Error(), "create instance: alias 'unknown': not found"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := tree.String(), "create subnet vpc=vpc-1234\ncreate instance subnet=@unknown"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+}
+
+func TestSortedParams(t *testing.T) {
+	tree := mustParse(t, "myinstance = create instance type={instance.type} subnet=$mysubnet name='my instance' group=@my-group")
+
+	expected := []KeyValue{
+		{"group", "@my-group"},
+		{"name", "'my instance'"},
+		{"subnet", "$mysubnet"},
+		{"type", "{instance.type}"},
+	}
+	for i := 0; i < 3; i++ {
+		if got, want := tree.Statements[0].SortedParams(), expected; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestNullValues(t *testing.T) {
+	tree := mustParse(t, "update instance description=null name=nullable")
+
+	params := tree.Statements[0].Params()
+	if got, want := params["description"], Null; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := params["name"], "nullable"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Canonical(), "update instance description=null name=nullable"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	reparsed := mustParse(t, tree.String())
+	if got, want := reparsed.Statements[0].Params()["description"], Null; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestValidateDuplicateIdentifiers(t *testing.T) {
+	tree := mustParse(t, "myvpc = create vpc cidr=10.0.0.0/16\n\n/* other\nvpc */\nmysubnet = create subnet vpc=$myvpc\nmyvpc =\n  create vpc cidr=10.0.0.0/24")
+
+	if got, want := tree.Statements[2].LineNumber, 6; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	errs := tree.Validate()
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d (%v), want %d", got, errs, want)
+	}
+	if got, want := errs[0].Error(), "duplicate identifier 'myvpc': declared line 1 and line 6"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if verr, ok := errs[0].(*ValidationError); !ok || verr.Statement != tree.Statements[2] || verr.Line != 6 {
+		t.Fatalf("got %#v, want validation error of statement line 6", errs[0])
+	}
+
+	if errs := mustParse(t, "myvpc = create vpc\nmysubnet = create subnet vpc=$myvpc").Validate(); len(errs) != 0 {
+		t.Fatalf("expected no error, got %v", errs)
+	}
+}
+
+func TestStatementValidators(t *testing.T) {
+	defer func(validators []func(*Statement) []error) { statementValidators = validators }(statementValidators)
+
+	RegisterStatementValidator(func(st *Statement) []error {
+		if expr := st.expression(); expr.Params["region"] == "us-west-1" {
+			return []error{NewValidationError(st, "forbidden-region", "%s %s: region us-west-1 is forbidden", expr.Action, expr.Entity)}
+		}
+		return nil
+	})
+	RegisterStatementValidator(func(st *Statement) []error { return nil })
+
+	tree := mustParse(t, "create vpc region=eu-west-1\ncreate subnet region=us-west-1\ninclude \"other.aws\"")
+	errs := tree.Validate()
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d (%v), want %d", got, errs, want)
+	}
+	verr, ok := errs[0].(*ValidationError)
+	if !ok || verr.Kind != "forbidden-region" || verr.Statement != tree.Statements[1] || verr.Line != 2 {
+		t.Fatalf("got %#v, want forbidden region error of statement line 2", errs[0])
+	}
+	if got, want := verr.Error(), "create subnet: region us-west-1 is forbidden"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if errs := mustParse(t, "# awless:disable forbidden-region\ncreate subnet region=us-west-1").Validate(); len(errs) != 0 {
+		t.Fatalf("expected no error, got %v", errs)
+	}
+}
+
+func TestValidateWarnsShadowedHoles(t *testing.T) {
+	tree := mustParse(t, "region = create vpc\ncreate subnet vpc=$region zone={region}\nwhen {region} create instance name={name}")
+	errs := tree.Validate()
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d (%v), want %d", got, errs, want)
+	}
+	verr, ok := errs[0].(*ValidationError)
+	if !ok || !verr.Warning || verr.Kind != "shadowed-hole" || verr.Statement != tree.Statements[1] {
+		t.Fatalf("got %#v, want shadowed hole warning of statement 2", errs[0])
+	}
+	if got, want := verr.Error(), "hole 'region' line 2 has the name of the identifier declared line 1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if errs := mustParse(t, "myvpc = create vpc\ncreate subnet vpc=$myvpc zone={region}").Validate(); len(errs) != 0 {
+		t.Fatalf("expected no warning, got %v", errs)
+	}
+}
+
+func TestFloatValues(t *testing.T) {
+	tcases := []struct {
+		input    string
+		expected float64
+		printed  string
+	}{
+		{input: "create metric value=1.5", expected: 1.5, printed: "1.5"},
+		{input: "create metric value=1.5e9", expected: 1.5e9, printed: "1.5e+09"},
+		{input: "create metric value=2E-3", expected: 0.002, printed: "0.002"},
+		{input: "create metric value=3e2", expected: 300, printed: "300.0"},
+	}
+	for _, tcase := range tcases {
+		tree := mustParse(t, tcase.input)
+		if got, want := tree.Statements[0].Params()["value"], tcase.expected; got != want {
+			t.Fatalf("%s: got %#v, want %#v", tcase.input, got, want)
+		}
+		if got, want := tree.String(), "create metric value="+tcase.printed; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+			t.Fatalf("%s: round trip failed, got %s", tcase.input, reparsed)
+		}
+	}
+
+	for _, malformed := range []string{"create metric value=1e", "create metric value=1.5e+", "create metric value=1.5.2"} {
+		if _, err := Parse(malformed); err == nil {
+			t.Fatalf("%s: expected error got none", malformed)
+		}
+	}
+
+	tree := mustParse(t, "create metric name='1.5'")
+	if got, want := tree.String(), "create metric name='1.5'"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	_, err := Parse("create tags name=x y=1e999")
+	if errs, ok := err.(ParseErrors); !ok || len(errs) != 1 || !strings.Contains(errs[0].Error(), "line 1: float '1e999' out of range") {
+		t.Fatalf("got %v, want out of range error", err)
+	}
+}
+
+func TestPortValues(t *testing.T) {
+	tree := mustParse(t, "update securitygroup portrange=443\ncreate listener port=80 count=70000\nupdate securitygroup portrange=80-443")
+	if got, want := tree.Statements[0].Params()["portrange"], Port(443); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Statements[1].Params()["port"], Port(80); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Statements[1].Params()["count"], 70000; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Statements[2].Params()["portrange"], (Range{Lo: 80, Hi: 443}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	_, err := Parse("create vpc\ncreate listener port=70000\nupdate securitygroup portrange=80-65536")
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	errs, ok := err.(ParseErrors)
+	if !ok {
+		t.Fatalf("got %T, want ParseErrors", err)
+	}
+	if got, want := len(errs), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := errs[0].Error(), "line 2: invalid port '70000': expecting 0-65535"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := errs[1].Line, 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestNamesWithDigits(t *testing.T) {
+	tree := mustParse(t, "web2 = create instance zone={0region} subnet=@subnet-1a\ncreate tags resource=$web2 key=Name\n2ndvpc = create vpc\nmy.hole-name_x = create subnet vpc=$2ndvpc.id")
+
+	if got, want := tree.Statements[0].Node.(*DeclarationNode).Left.Ident, "web2"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[0].Node.(*DeclarationNode).Right.Holes["zone"], "0region"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[0].Node.(*DeclarationNode).Right.Aliases["subnet"], "subnet-1a"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[1].Node.(*ExpressionNode).Refs["resource"], "web2"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[3].Node.(*DeclarationNode).Right.Refs["vpc"], "2ndvpc.id"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	for _, invalid := range []string{"create tags resource=$1", "create instance zone={42}", "12 = create vpc", "create 2vpc"} {
+		if _, err := Parse(invalid); err == nil {
+			t.Fatalf("%s: expected error got none", invalid)
+		}
+	}
+}
+
+func TestStatementKind(t *testing.T) {
+	tree := mustParse(t, "myvpc = create vpc\n# comment\ndelete instance id=i-54g3hj\nvar name=web\ninclude \"vpc.aws\"")
+
+	expected := []StatementKind{KindDeclaration, KindExpression, KindVar, KindInclude}
+	if got, want := len(tree.Statements), len(expected); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for i, st := range tree.Statements {
+		if got, want := st.Kind(), expected[i]; got != want {
+			t.Fatalf("%d: got %s, want %s", i, got, want)
+		}
+	}
+	if got, want := tree.Statements[0].Action(), "create"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[1].Params()["id"], "i-54g3hj"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestVarDeclarations(t *testing.T) {
+	tree := mustParse(t, "create vpc\n\nvar a=1, b=web, \"my c\"='x y'\ncreate instance name=$b")
+
+	expected := []*VarNode{{Ident: "a", Value: 1}, {Ident: "b", Value: "web"}, {Ident: "my c", Value: "x y"}}
+	if got, want := len(tree.Statements), len(expected)+2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for i, want := range expected {
+		st := tree.Statements[i+1]
+		if got := st.Node; !got.equal(want) {
+			t.Fatalf("%d: got %#v, want %#v", i, got, want)
+		}
+		if got, want := st.LineNumber, 3; got != want {
+			t.Fatalf("%d: got line %d, want %d", i, got, want)
+		}
+	}
+	if got, want := tree.String(), "create vpc\n\nvar a=1\nvar b=web\nvar \"my c\"='x y'\ncreate instance name=$b"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+	if got, want := mustParse(t, "var a=1\ncreate vpc cidr=10.0.0.0/16").Statements[1].Node.(*ExpressionNode).Raw, map[string]string{"cidr": "10.0.0.0/16"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for _, src := range []string{"var a=$b", "var a={hole}", "var _=1", "var a=1, b"} {
+		if _, err := Parse(src); err == nil {
+			t.Fatalf("%s: expected error got none", src)
+		}
+	}
+	if _, err := Parse("var a=$b"); !strings.Contains(err.Error(), "var 'a': expecting a literal value") {
+		t.Fatalf("got %s, want literal value error", err)
+	}
+	errs := mustParse(t, "var a=1, a=2").Validate()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "duplicate identifier 'a'") {
+		t.Fatalf("got %v, want duplicate identifier error", errs)
+	}
+}
+
+func TestListValues(t *testing.T) {
+	tree := mustParse(t, "create securitygroup cidr=10.0.0.0/8,10.1.0.0/8 name='a,b' description=\"c,d\"")
+
+	params := tree.Statements[0].Params()
+	if got, want := params["cidr"], []string{"10.0.0.0/8", "10.1.0.0/8"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := params["name"], "a,b"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := params["description"], "c,d"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Canonical(), "create securitygroup cidr=10.0.0.0/8,10.1.0.0/8 description='c,d' name='a,b'"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	if _, err := Parse("create securitygroup cidr=10.0.0.0/8,"); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestRefsInListValues(t *testing.T) {
+	tree := mustParse(t, "create loadbalancer subnets=[$a, @b,{c}, subnet-1234abcd]")
+	expr := tree.Statements[0].Node.(*ExpressionNode)
+
+	if got, want := expr.Refs, map[string]string{"subnets[0]": "a"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Aliases, map[string]string{"subnets[1]": "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Holes, map[string]string{"subnets[2]": "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Params, map[string]interface{}{"subnets": []interface{}{nil, nil, nil, ResourceID{Prefix: "subnet", Suffix: "1234abcd"}}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.String(), "create loadbalancer subnets=[$a,@b,{c},subnet-1234abcd]"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Canonical(), "create loadbalancer subnets=[$a,@b,{c},subnet-1234abcd]"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	expr.ProcessRefs(map[string]interface{}{"a": "subnet-1"})
+	if _, err := expr.ProcessHoles(map[string]interface{}{"c": "subnet-3"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.ResolveAliases(func(entity, alias string) (string, error) { return "subnet-2", nil }); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := expr.Params, map[string]interface{}{"subnets": []interface{}{"subnet-1", "subnet-2", "subnet-3", ResourceID{Prefix: "subnet", Suffix: "1234abcd"}}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.String(), "create loadbalancer subnets=[subnet-1,subnet-2,subnet-3,subnet-1234abcd]"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	tree = mustParse(t, "create loadbalancer subnets=[]")
+	if got, want := tree.Statements[0].Params()["subnets"], []interface{}{}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestValueOnNextLine(t *testing.T) {
+	tree := mustParse(t, "myvpc =\n  create vpc cidr =\n  10.0.0.0/16 name=\n\tmyvpc\ncreate subnet vpc=\n$myvpc")
+	if got, want := len(tree.Statements), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := tree.Canonical(), "myvpc = create vpc cidr=10.0.0.0/16 name=myvpc\ncreate subnet vpc=$myvpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[1].LineNumber, 5; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	if _, err := Parse("create vpc cidr=\ncreate subnet vpc=$myvpc"); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestGoString(t *testing.T) {
+	tree := mustParse(t, "create vpc\nmysubnet = create subnet vpc=$myvpc cidr=10.0.0.0/24 name={subnet.name}")
+
+	if got, want := fmt.Sprintf("%#v", tree.Statements[1]), `&ast.Statement{Line: 2, Node: &ast.DeclarationNode{Left: &ast.IdentifierNode{Ident: "mysubnet"}, Right: &ast.ExpressionNode{Action: "create", Entity: "subnet", Params: [cidr=10.0.0.0/24 name={subnet.name} vpc=$myvpc]}}}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	tree.Statements[0].Err = errors.New("failed")
+	if got, want := fmt.Sprintf("%#v", tree.Statements[0]), `&ast.Statement{Line: 1, Node: &ast.ExpressionNode{Action: "create", Entity: "vpc", Params: []}, Err: "failed"}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestResourceIdValues(t *testing.T) {
+	tree := mustParse(t, "create instance id=i-0123456789abcdef0 subnet=vpc-1a2b3c4d name=web-1 image=ami-123456 ports=80-443 group=sg-9999-x tag=my-deadbeef")
+
+	params := tree.Statements[0].Params()
+	if got, want := params["id"], (ResourceID{Prefix: "i", Suffix: "0123456789abcdef0"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := params["subnet"], (ResourceID{Prefix: "vpc", Suffix: "1a2b3c4d"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	for key, want := range map[string]interface{}{"name": "web-1", "image": "ami-123456", "ports": Range{Lo: 80, Hi: 443}, "group": "sg-9999-x", "tag": "my-deadbeef"} {
+		if got := params[key]; got != want {
+			t.Fatalf("%s: got %#v, want %#v", key, got, want)
+		}
+	}
+	if got, want := tree.Canonical(), "create instance group=sg-9999-x id=i-0123456789abcdef0 image=ami-123456 name=web-1 ports=80-443 subnet=vpc-1a2b3c4d tag=my-deadbeef"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+}
+
+func TestListValuesTrailingComma(t *testing.T) {
+	tree := mustParse(t, "create loadbalancer subnets=[$a, b ,] groups=[] zones=[ ]")
+
+	params := tree.Statements[0].Params()
+	if got, want := params["subnets"], []interface{}{nil, "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	for _, key := range []string{"groups", "zones"} {
+		if got, want := params[key], []interface{}{}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: got %#v, want %#v", key, got, want)
+		}
+	}
+	if got, want := tree.Canonical(), "create loadbalancer groups=[] subnets=[$a,b] zones=[]"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	for _, src := range []string{"create loadbalancer subnets=[,]", "create loadbalancer subnets=[a,,]"} {
+		if _, err := Parse(src); err == nil {
+			t.Fatalf("%s: expected error got none", src)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tree := mustParse(t, "myvpc = create vpc\ncreate subnet vpc=$myvpc\ndelete subnet id=subnet-1234\ncreate instance subnet=$mysubnet")
+	tree.Statements = append(tree.Statements, &Statement{Node: &IdentifierNode{Ident: "unknown"}})
+
+	tcases := []struct {
+		action, entity string
+		expect         []int
+	}{
+		{action: "create", expect: []int{0, 1, 3}},
+		{entity: "subnet", expect: []int{1, 2}},
+		{action: "create", entity: "subnet", expect: []int{1}},
+		{action: "start", entity: "subnet"},
+		{expect: []int{0, 1, 2, 3}},
+	}
+	for i, tcase := range tcases {
+		var want []*Statement
+		for _, j := range tcase.expect {
+			want = append(want, tree.Statements[j])
+		}
+		if got := tree.Filter(tcase.action, tcase.entity); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+}
+
+func TestGuardedStatements(t *testing.T) {
+	tree := mustParse(t, "when {useLarge} create instance type=large\nwhen false myvpc = create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$myvpc\nwhen true delete subnet id=$mysubnet")
+
+	expected := []*Guard{{Hole: "useLarge"}, {Value: false}, nil, {Value: true}}
+	for i, want := range expected {
+		if got := tree.Statements[i].Guard; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+	if got, want := tree.Statements[0].Entity(), "instance"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.String(), "when {useLarge} create instance type=large\nwhen false myvpc = create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$myvpc\nwhen true delete subnet id=$mysubnet"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+	if clone := tree.Clone(); !clone.Equal(tree) || clone.Statements[0].Guard == tree.Statements[0].Guard {
+		t.Fatal("expected guards to be copied when cloning")
+	}
+	if mustParse(t, "create instance type=large").Equal(mustParse(t, "when true create instance type=large")) {
+		t.Fatal("expected guarded and unguarded statements to differ")
+	}
+
+	if _, err := Parse("when {} create instance"); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestProcessRefsReportsUnresolved(t *testing.T) {
+	tree := mustParse(t, "create subnet vpc=$myvpc\ncreate instance subnet=$mysubnet keypair=$mykey.name")
+	expr := tree.Statements[1].Node.(*ExpressionNode)
+
+	if got, want := expr.ProcessRefs(map[string]interface{}{"mysubnet": "subnet-1234", "mykey": map[string]interface{}{}}), []string{"keypair"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := expr.Params["subnet"], "subnet-1234"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if got, want := tree.ProcessAllRefs(map[string]interface{}{"mykey": map[string]interface{}{"name": "key"}}), []string{"subnet.vpc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := expr.Params["keypair"], "key"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := tree.ProcessAllRefs(map[string]interface{}{"myvpc": "vpc-1234"}); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+}
+
+func TestRangeValues(t *testing.T) {
+	tree := mustParse(t, "create alarm a=0.0-1.5 b=-5-5 c=-10--5 d=80-443")
+
+	params := tree.Statements[0].Params()
+	expected := map[string]interface{}{
+		"a": Range{Lo: 0, Hi: 1.5},
+		"b": Range{Lo: -5, Hi: 5},
+		"c": Range{Lo: -10, Hi: -5},
+		"d": Range{Lo: 80, Hi: 443},
+	}
+	for key, want := range expected {
+		if got := params[key]; got != want {
+			t.Fatalf("%s: got %#v, want %#v", key, got, want)
+		}
+	}
+	if got, want := tree.Canonical(), "create alarm a=0-1.5 b=-5-5 c=-10--5 d=80-443"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	for _, src := range []string{"create alarm a=5--3", "create alarm a=1.5-0.5", "update securitygroup portrange=80.5-90"} {
+		if _, err := Parse(src); err == nil {
+			t.Fatalf("%s: expected error got none", src)
+		}
+	}
+}
+
+func TestMultipleAssignment(t *testing.T) {
+	tree := mustParse(t, "mykey , mysecret = create accesskey user=jdoe\ncreate tags key=$mykey value=$mysecret\nmyvpc = create vpc")
+
+	decl, ok := tree.Statements[0].Node.(*DeclarationNode)
+	if !ok {
+		t.Fatalf("got %T, want declaration", tree.Statements[0].Node)
+	}
+	var idents []string
+	for _, ident := range decl.Idents() {
+		idents = append(idents, ident.Ident)
+	}
+	if got, want := idents, []string{"mykey", "mysecret"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := tree.Canonical(), "mykey, mysecret = create accesskey user=jdoe\ncreate tags key=$mykey value=$mysecret\nmyvpc = create vpc"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+	if tree.Equal(mustParse(t, "mykey = create accesskey user=jdoe\ncreate tags key=$mykey value=$mysecret\nmyvpc = create vpc")) {
+		t.Fatal("expected declarations with different identifiers to differ")
+	}
+
+	sliced, err := tree.Slice("mysecret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(sliced.Statements), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := len(tree.PruneUnused().Statements), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if errs := mustParse(t, "a, b = create accesskey\nb = create vpc").Validate(); len(errs) != 1 {
+		t.Fatalf("got %v, want 1 duplicate error", errs)
+	}
+}
+
+func TestOrderedHoles(t *testing.T) {
+	tree := mustParse(t, "create vpc name={vpc.name} cidr={vpc.cidr:cidr}\n\nwhen {large} create instance vpc={vpc.name} type={instance.type} count=1")
+
+	expected := []HoleRef{
+		{Name: "vpc.name", Line: 1, Statement: tree.Statements[0]},
+		{Name: "vpc.cidr", Line: 1, Statement: tree.Statements[0], Type: "cidr"},
+		{Name: "large", Line: 3, Statement: tree.Statements[1]},
+		{Name: "instance.type", Line: 3, Statement: tree.Statements[1]},
+	}
+	for i := 0; i < 20; i++ {
+		if got, want := tree.OrderedHoles(), expected; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	}
+
+	clone := tree.Clone()
+	if got, want := clone.OrderedHoles()[1].Name, "vpc.cidr"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestQualifiedAliases(t *testing.T) {
+	tree := mustParse(t, "create instance group=@prod/web subnet=@a/b/c role=@team:db-1 key=@my.key")
+
+	expected := map[string]string{"group": "prod/web", "subnet": "a/b/c", "role": "team:db-1", "key": "my.key"}
+	if got, want := tree.Statements[0].Node.(*ExpressionNode).Aliases, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	for _, src := range []string{"create instance group=@prod/", "create instance group=@prod/ name=x", "create instance group=@/web", "create instance group=@prod::web"} {
+		if _, err := Parse(src); err == nil {
+			t.Fatalf("%s: expected error got none", src)
+		}
+	}
+}
+
+func TestResolveParamRefs(t *testing.T) {
+	tree := mustParse(t, "myvpc = create vpc cidr=10.0.0.0/16 name=$myname\nmysubnet = create subnet vpc=$myvpc cidr=$myvpc.params.cidr\ncreate instance subnet=$mysubnet name=$mysubnet.params.cidr")
+	if err := tree.ResolveParamRefs(); err != nil {
+		t.Fatal(err)
+	}
+	subnet := tree.Statements[1].expression()
+	if got, want := subnet.Params["cidr"], "10.0.0.0/16"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := subnet.Refs, map[string]string{"vpc": "myvpc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := tree.Statements[2].Params()["name"], "10.0.0.0/16"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	tcases := []struct {
+		input, err string
+	}{
+		{input: "create subnet cidr=$myvpc.params.cidr\nmyvpc = create vpc cidr=10.0.0.0/16", err: "create subnet: ref '$myvpc.params.cidr': forward reference to 'myvpc'"},
+		{input: "myvpc = create vpc cidr=$myvpc.params.cidr", err: "create vpc: ref '$myvpc.params.cidr': forward reference to 'myvpc'"},
+		{input: "create subnet cidr=$myvpc.params.cidr", err: "create subnet: ref '$myvpc.params.cidr': no declaration 'myvpc'"},
+		{input: "myvpc = create vpc cidr={vpc.cidr}\ncreate subnet cidr=$myvpc.params.cidr", err: "create subnet: ref '$myvpc.params.cidr': 'myvpc' has no literal param 'cidr'"},
+	}
+	for _, tcase := range tcases {
+		tree := mustParse(t, tcase.input)
+		err := tree.ResolveParamRefs()
+		if err == nil {
+			t.Fatalf("%s: expected error got none", tcase.input)
+		}
+		if got, want := err.Error(), tcase.err; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	}
+}
+
+func TestStatementMeta(t *testing.T) {
+	tree := mustParse(t, "create instance type={instance.type}")
+	tree.Statements[0].Meta = map[string]interface{}{"cost": 12.5, "approvers": []string{"alice"}}
+
+	clone := tree.Clone()
+	if got, want := clone.Statements[0].Meta, tree.Statements[0].Meta; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	clone.Statements[0].Meta["cost"] = 0
+	clone.Statements[0].Meta["approvers"].([]string)[0] = "bob"
+	if got, want := tree.Statements[0].Meta, map[string]interface{}{"cost": 12.5, "approvers": []string{"alice"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	filled, err := tree.WithHoles(map[string]interface{}{"instance.type": "t2.micro"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := filled.Statements[0].Meta["cost"], 12.5; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestIntLiterals(t *testing.T) {
+	tree := mustParse(t, "create instance a=1_000 b=0o755 c=0 d=0755 e=1_000_000 f=089")
+
+	params := tree.Statements[0].Params()
+	expected := map[string]interface{}{"a": 1000, "b": 493, "c": 0, "d": 493, "e": 1000000, "f": 89}
+	for key, want := range expected {
+		if got := params[key]; got != want {
+			t.Fatalf("%s: got %#v, want %#v", key, got, want)
+		}
+	}
+	if got, want := mustParse(t, "create listener port=8_080").Statements[0].Params()["port"], Port(8080); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	for _, src := range []string{"create instance count=1__0", "create instance count=1_", "create instance mode=0o789", "create instance mode=0o"} {
+		if _, err := Parse(src); err == nil {
+			t.Fatalf("%s: expected error got none", src)
+		}
+	}
+	if _, err := Parse("create instance count=1__0"); !strings.Contains(err.Error(), "invalid int '1__0'") {
+		t.Fatalf("got %s, want invalid int error", err)
+	}
+}
+
+func TestHoleNames(t *testing.T) {
+	tree := mustParse(t, "when {_large} create instance name={instance.name} type={my-type_2}")
+	if got, want := len(tree.OrderedHoles()), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	_, err := Parse("create vpc\ncreate instance name={-rf}\nwhen {-x} create subnet\ncreate tags key={.key}")
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	errs, ok := err.(ParseErrors)
+	if !ok {
+		t.Fatalf("got %T, want ParseErrors", err)
+	}
+	if got, want := len(errs), 3; got != want {
+		t.Fatalf("got %d (%s), want %d", got, err, want)
+	}
+	if got, want := errs[0].Error(), "line 2: invalid hole name '-rf': expecting letters, digits, '_', '.' or '-', not starting with '.' or '-'"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := errs[1].Line, 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestReplaceParam(t *testing.T) {
+	tree, err := Parse(`create instance type=t2.micro count=1
+web = create instance type=t2.micro count=2
+create instance type=t2.large count=1
+create volume type=t2.micro`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.ReplaceParam("type", "t2.micro", "t3.micro"), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := tree.ReplaceParam("count", "1", 5), 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := tree.ReplaceParam("count", 1, 5), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	var got []string
+	for _, st := range tree.Statements {
+		got = append(got, fmt.Sprintf("%v %v", st.Params()["type"], st.Params()["count"]))
+	}
+	if want := []string{"t3.micro 5", "t3.micro 2", "t2.large 5", "t3.micro <nil>"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got := tree.RawString(); strings.Contains(got, "t2.micro") {
+		t.Fatalf("got %s, want replaced values", got)
+	}
+}
+
+func TestGuardExpressions(t *testing.T) {
+	tree := mustParse(t, "when !{dryrun} create vpc\nwhen {n} > 3 create instance\nwhen {env}=='prod' create subnet\nwhen ! {ratio} <= -0.5 create volume\nwhen {ok} != false delete vpc")
+
+	expected := []*Guard{
+		{Hole: "dryrun", Not: true},
+		{Hole: "n", Op: ">", Operand: 3},
+		{Hole: "env", Op: "==", Operand: "prod"},
+		{Hole: "ratio", Not: true, Op: "<=", Operand: -0.5},
+		{Hole: "ok", Op: "!=", Operand: false},
+	}
+	for i, want := range expected {
+		if got := tree.Statements[i].Guard; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+	if got, want := tree.String(), "when !{dryrun} create vpc\nwhen {n} > 3 create instance\nwhen {env} == 'prod' create subnet\nwhen !{ratio} <= -0.5 create volume\nwhen {ok} != false delete vpc"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	tcases := []struct {
+		guard int
+		fills map[string]interface{}
+		want  bool
+	}{
+		{0, map[string]interface{}{"dryrun": true}, false},
+		{0, map[string]interface{}{"dryrun": "false"}, true},
+		{1, map[string]interface{}{"n": 4}, true},
+		{1, map[string]interface{}{"n": "3"}, false},
+		{2, map[string]interface{}{"env": "prod"}, true},
+		{2, map[string]interface{}{"env": "dev"}, false},
+		{3, map[string]interface{}{"ratio": 0.2}, true},
+		{4, map[string]interface{}{"ok": true}, true},
+	}
+	for i, tcase := range tcases {
+		got, err := tree.Statements[tcase.guard].Guard.Eval(tcase.fills)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got != tcase.want {
+			t.Fatalf("%d: got %t, want %t", i+1, got, tcase.want)
+		}
+	}
+
+	errcases := []struct {
+		guard int
+		fills map[string]interface{}
+	}{
+		{0, map[string]interface{}{}},
+		{0, map[string]interface{}{"dryrun": "maybe"}},
+		{1, map[string]interface{}{"n": "many"}},
+		{4, map[string]interface{}{"ok": 1}},
+	}
+	for i, tcase := range errcases {
+		if _, err := tree.Statements[tcase.guard].Guard.Eval(tcase.fills); err == nil {
+			t.Fatalf("%d: expected error got none", i+1)
+		}
+	}
+	if _, err := (&Guard{Value: true, Op: ">", Operand: false}).Eval(nil); err == nil {
+		t.Fatal("expected error for ordering booleans")
+	}
+}
+
+func TestExpressionStringSpacing(t *testing.T) {
+	tcases := []struct {
+		expr *ExpressionNode
+		want string
+	}{
+		{&ExpressionNode{Action: "create", Entity: "vpc"}, "create vpc"},
+		{&ExpressionNode{Action: "create", Entity: "vpc", Params: map[string]interface{}{}}, "create vpc"},
+		{&ExpressionNode{Action: "create", Entity: "vpc", Params: map[string]interface{}{"cidr": "10.0.0.0/16"}}, "create vpc cidr=10.0.0.0/16"},
+	}
+	for i, tcase := range tcases {
+		got := tcase.expr.String()
+		if got != tcase.want {
+			t.Fatalf("%d: got %q, want %q", i+1, got, tcase.want)
+		}
+		if strings.HasSuffix(got, " ") || strings.Contains(got, "  ") {
+			t.Fatalf("%d: unexpected spacing in %q", i+1, got)
+		}
+	}
+}
+
+func TestQuotedNames(t *testing.T) {
+	src := `"my resource" = create vpc cidr=10.0.0.0/16
+create subnet vpc=$"my resource" group=@"web servers/eu" image={"image id"}
+create instance subnet=@prod/web-sg name={name}`
+	tree := mustParse(t, src)
+
+	decl := tree.Statements[0].Node.(*DeclarationNode)
+	if got, want := decl.Left.Ident, "my resource"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	expr := tree.Statements[1].Node.(*ExpressionNode)
+	if got, want := expr.Refs["vpc"], "my resource"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := expr.Aliases["group"], "web servers/eu"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := expr.Holes["image"], "image id"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if got, want := tree.Canonical(), `"my resource" = create vpc cidr=10.0.0.0/16
+create subnet vpc=$"my resource" group=@"web servers/eu" image={"image id"}
+create instance subnet=@prod/web-sg name={name}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	if _, err := Parse(`create subnet name={""}`); err == nil {
+		t.Fatal("expected error for empty hole name")
+	}
+}
+
+func TestInterpolatedStrings(t *testing.T) {
+	tree := mustParse(t, `create instance name="web-{env}-01" literal='web-{env}'
+create subnet name="{ project }-{env}.{zone}"`)
+
+	instance := tree.Statements[0].Node.(*ExpressionNode)
+	if got, want := instance.Params["name"], (Interpolation{{Text: "web-"}, {Hole: "env"}, {Text: "-01"}}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := instance.Params["literal"], "web-{env}"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Canonical(), "create instance literal='web-{env}' name=\"web-{env}-01\"\ncreate subnet name=\"{project}-{env}.{zone}\""; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+	var holes []string
+	for _, h := range tree.OrderedHoles() {
+		holes = append(holes, h.Name)
+	}
+	if got, want := holes, []string{"env", "project", "zone"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := instance.ProcessHoles(map[string]interface{}{"env": "prod"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := instance.Params["name"], "web-prod-01"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	subnet := tree.Statements[1].Node.(*ExpressionNode)
+	subnet.ProcessHoles(map[string]interface{}{"env": "dev", "zone": 2})
+	if got, want := subnet.String(), "create subnet name=\"{project}-dev.2\""; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	subnet.ProcessHoles(map[string]interface{}{"project": "acme"})
+	if got, want := subnet.Params["name"], "acme-dev.2"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := len(subnet.Holes), 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestEscapedBraces(t *testing.T) {
+	tree := mustParse(t, `create function policy="\{\"effect\": \"allow\"\}" name="\{literal\}-{env}"
+create function policy="it's \{ok\}"`)
+
+	expr := tree.Statements[0].Node.(*ExpressionNode)
+	if got, want := expr.Params["policy"], `{"effect": "allow"}`; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Params["name"], (Interpolation{{Text: "{literal}-"}, {Hole: "env"}}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Holes, map[string]string{"name[1]": "env"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Statements[1].Params()["policy"], "it's {ok}"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Canonical(), `create function name="\{literal\}-{env}" policy='{"effect": "allow"}'`+"\n"+`create function policy="it's \{ok\}"`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.Canonical()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	expr.ProcessHoles(map[string]interface{}{"env": "prod"})
+	if got, want := expr.Params["name"], "{literal}-prod"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	tree := mustParse(t, `# network
+myvpc = create vpc cidr=10.0.0.0/16
+create subnet vpc=$myvpc
+include "storage.aws"
+create instance subnet=$mysubnet
+delete subnet id=@old
+create bucket name=logs
+start instance id=$myinstance`)
+	if got, want := tree.Entities(), []string{"bucket", "instance", "subnet", "vpc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := (&AST{}).Entities(); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	tree := mustParse(t, `# network
+myvpc = create vpc cidr=10.0.0.0/16
+create instance subnet=$mysubnet
+when {large} create instance type=t2.large
+include "storage.aws"
+inst1, inst2 = create instance count=2
+delete volume id=vol-123456
+/* done */`)
+	want := map[string]int{"create vpc": 1, "create instance": 3, "delete volume": 1}
+	if got := tree.Summary(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := (&AST{}).Summary(); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+}
+
+func TestRegexMatchParams(t *testing.T) {
+	tree := mustParse(t, "list instances name=~web- type=t2.micro\nlist buckets name =~ 'logs (eu|us)$' owner=~it's")
+	expr := tree.Statements[0].Node.(*ExpressionNode)
+	if got, want := expr.Params["name"], "web-"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Matches, map[string]bool{"name": true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := tree.Canonical(), "list instances name=~web- type=t2.micro\nlist buckets name=~'logs (eu|us)$' owner=~it's"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+	if clone := tree.Clone(); !clone.Equal(tree) {
+		t.Fatal("expected clone to keep regex params")
+	}
+	if tree.Equal(mustParse(t, "list instances name=web- type=t2.micro\nlist buckets name =~ 'logs (eu|us)$' owner=~it's")) {
+		t.Fatal("expected regex and literal params to differ")
+	}
+
+	_, err := Parse("list instances name=~web\nlist instances name=~web-(\nlist buckets name=~[a-")
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	errs, ok := err.(ParseErrors)
+	if !ok || len(errs) != 2 || errs[0].Line != 2 || errs[1].Line != 3 {
+		t.Fatalf("got %#v, want errors of lines 2 and 3", err)
+	}
+	if got, want := errs[0].Error(), "invalid regex 'web-('"; !strings.Contains(got, want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestStringRedacted(t *testing.T) {
+	tree := mustParse(t, `create user name=jdoe password='s3cr3t p4ss'
+create database password={db.password} username=admin
+create accesskey secret=[abc,$mysecret,{other}] user=jdoe`)
+	got := tree.StringRedacted([]string{"password", "secret"})
+	lines := strings.Split(got, "\n")
+	want := [][]string{
+		{"create user", "name=jdoe", "password=***"},
+		{"create database", "password={db.password}", "username=admin"},
+		{"create accesskey", "secret=***", "user=jdoe"},
+	}
+	for i, line := range lines {
+		for _, part := range want[i] {
+			if !strings.Contains(line, part) {
+				t.Fatalf("%d: got %s, want it to contain %s", i+1, line, part)
+			}
+		}
+	}
+	if strings.Contains(got, "s3cr3t") || strings.Contains(got, "abc") || strings.Contains(got, "mysecret") {
+		t.Fatalf("got %s, want secrets redacted", got)
+	}
+	if !strings.Contains(tree.String(), "s3cr3t") {
+		t.Fatal("expected original template untouched")
+	}
+}
+
+func TestSizeValues(t *testing.T) {
+	tcases := []struct {
+		input string
+		size  Size
+		bytes int64
+	}{
+		{"100gb", Size{100, "gb"}, 100 << 30},
+		{"512mb", Size{512, "mb"}, 512 << 20},
+		{"512MB", Size{512, "mb"}, 512 << 20},
+		{"4Kb", Size{4, "kb"}, 4096},
+		{"2tb", Size{2, "tb"}, 2 << 40},
+	}
+	for _, tcase := range tcases {
+		tree := mustParse(t, "create volume size="+tcase.input)
+		got, ok := tree.Statements[0].Params()["size"].(Size)
+		if !ok || got != tcase.size {
+			t.Fatalf("%s: got %#v, want %#v", tcase.input, got, tcase.size)
+		}
+		if got, want := got.Bytes(), tcase.bytes; got != want {
+			t.Fatalf("%s: got %d, want %d", tcase.input, got, want)
+		}
+		if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+			t.Fatalf("%s: round trip failed, got %s", tcase.input, reparsed)
+		}
+	}
+
+	tree := mustParse(t, "create volume size=100 count=10 name='100gb'")
+	params := tree.Statements[0].Params()
+	if got, want := params["size"], 100; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+	if _, err := Parse("create volume size=100gbx"); err == nil {
+		t.Fatal("expected error for int followed by a word")
+	}
+	if _, err := Parse("create volume size=99999999999tb"); err == nil {
+		t.Fatal("expected out of range error")
+	}
+}
+
+func TestCascadeModifier(t *testing.T) {
+	tree := mustParse(t, "delete vpc id=$x cascade\ndelete subnet cascade id=@old\ndelete vpc id=vpc-1234 cascade=true\ndelete vpc id=vpc-1234")
+	expr := tree.Statements[0].Node.(*ExpressionNode)
+	if got, want := expr.Modifiers, map[string]bool{CascadeModifier: true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Refs["id"], "x"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if !tree.Statements[1].Node.(*ExpressionNode).Modifiers[CascadeModifier] {
+		t.Fatal("expected cascade modifier before params")
+	}
+	third := tree.Statements[2].Node.(*ExpressionNode)
+	if got, want := third.Params["cascade"], "true"; got != want || len(third.Modifiers) != 0 {
+		t.Fatalf("got %#v (modifiers %v), want %q param", got, third.Modifiers, want)
+	}
+	if got, want := tree.Canonical(), "delete vpc id=$x cascade\ndelete subnet id=@old cascade\ndelete vpc cascade=true id=vpc-1234\ndelete vpc id=vpc-1234"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[0].String(), "delete vpc id=$x cascade"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if tree.Statements[3].Node.equal(tree.Clone().Statements[0].Node) || !tree.Statements[0].Node.equal(tree.Clone().Statements[0].Node) {
+		t.Fatal("expected modifiers compared and cloned")
+	}
+
+	if _, err := Parse("create vpc cidr=10.0.0.0/16 cascade"); err == nil || !strings.Contains(err.Error(), "modifier 'cascade' only applies to delete statements") {
+		t.Fatalf("got %v, want modifier error", err)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	tree := mustParse(t, "delete instance --force id=@web --wait\nstop instance --wait --force\ncreate instance name=--force\ndelete vpc id=$x --force cascade")
+	expr := tree.Statements[0].Node.(*ExpressionNode)
+	if got, want := expr.Flags, []string{"force", "wait"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := expr.Aliases["id"], "web"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if !expr.HasFlag("wait") || expr.HasFlag("dry-run") {
+		t.Fatal("unexpected HasFlag result")
+	}
+	if got, want := tree.Statements[1].String(), "stop instance --wait --force"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[2].Params()["name"], "--force"; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	last := tree.Statements[3].Node.(*ExpressionNode)
+	if !last.HasFlag("force") || !last.Modifiers[CascadeModifier] {
+		t.Fatalf("got flags %q modifiers %v, want force flag and cascade", last.Flags, last.Modifiers)
+	}
+	if got, want := tree.Canonical(), "delete instance id=@web --force --wait\nstop instance --force --wait\ncreate instance name=--force\ndelete vpc id=$x --force cascade"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+	if mustParse(t, "stop instance --force").Equal(mustParse(t, "stop instance --wait")) {
+		t.Fatal("expected flags compared")
+	}
+
+	for _, bad := range []string{"delete instance force", "delete instance --force --force", "delete instance --"} {
+		if _, err := Parse(bad); err == nil {
+			t.Fatalf("%s: expected error", bad)
+		}
+	}
+}
+
+func TestPercentOfValues(t *testing.T) {
+	tree := mustParse(t, "max = create scalinggroup size=10\nupdate scalinggroup capacity=50%of$max name=web\nupdate scalinggroup capacity=12.5%of$max.params.size")
+	expr := tree.Statements[1].Node.(*ExpressionNode)
+	if got, want := expr.Params["capacity"], (PercentOf{Percent: 50, Ref: "max"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := expr.Refs, map[string]string{"capacity[0]": "max"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Statements[2].String(), "update scalinggroup capacity=12.5%of$max.params.size"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got := tree.Dependents("max"); len(got) != 2 {
+		t.Fatalf("got %v, want 2 dependents", got)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	if err := tree.Rename("max", "maxsize"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.Statements[1].Node.(*ExpressionNode).Params["capacity"].(PercentOf).Ref, "maxsize"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err := expr.Params["capacity"].(PercentOf).Compute(); err == nil {
+		t.Fatal("expected unresolved ref error")
+	}
+	expr.ProcessRefs(map[string]interface{}{"maxsize": 8})
+	p, ok := expr.Params["capacity"].(PercentOf)
+	if !ok || len(expr.Refs) != 0 {
+		t.Fatalf("got %#v (refs %v), want resolved percentage", expr.Params["capacity"], expr.Refs)
+	}
+	if got, err := p.Compute(); err != nil || got != 4 {
+		t.Fatalf("got %v (%v), want 4", got, err)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	tree := mustParse(t, "myvpc = create vpc cidr=10.0.0.0/16")
+
+	subnet := &Statement{Node: &DeclarationNode{
+		Left:  &IdentifierNode{Ident: "mysubnet"},
+		Right: &ExpressionNode{Action: "create", Entity: "subnet", Refs: map[string]string{"vpc": "myvpc"}},
+	}}
+	if err := tree.Append(subnet); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.Append(&Statement{Node: &ExpressionNode{Action: "create", Entity: "instance", Refs: map[string]string{"subnet": "mysubnet"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.String(), "myvpc = create vpc cidr=10.0.0.0/16\nmysubnet = create subnet vpc=$myvpc\ncreate instance subnet=$mysubnet"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	conflicts := []*Statement{
+		{Node: &DeclarationNode{Left: &IdentifierNode{Ident: "myvpc"}, Right: &ExpressionNode{Action: "create", Entity: "vpc"}}},
+		{Node: &DeclarationNode{Left: &IdentifierNode{Ident: "key"}, Extra: []*IdentifierNode{{Ident: "mysubnet"}}, Right: &ExpressionNode{Action: "create", Entity: "accesskey"}}},
+		{Node: &DeclarationNode{Left: &IdentifierNode{Ident: "key"}, Extra: []*IdentifierNode{{Ident: "key"}}, Right: &ExpressionNode{Action: "create", Entity: "accesskey"}}},
+		nil,
+	}
+	for _, st := range conflicts {
+		if err := tree.Append(st); err == nil {
+			t.Fatalf("%v: expected error", st)
+		}
+	}
+	if got, want := len(tree.Statements), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if err := tree.Append(conflicts[0]); err == nil || err.Error() != "append: identifier 'myvpc' already declared" {
+		t.Fatalf("got %v, want duplicate identifier error", err)
+	}
+}
+
+func TestColorValues(t *testing.T) {
+	tree := mustParse(t, "# colors\ncreate tag key=team color=#1a2b3c # blue\n#1a2b3c\ncreate tag key=other color=#FFFFFF\nupdate tag colors=[#1a2b3c,#000000]")
+	if got, want := len(tree.Statements), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := tree.Statements[0].Params()["color"], Color("#1a2b3c"); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Statements[1].Params()["color"], Color("#FFFFFF"); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := tree.Statements[2].Params()["colors"], []interface{}{Color("#1a2b3c"), Color("#000000")}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+
+	for _, bad := range []string{"create tag color=#1a2b3", "create tag color=#1a2b3c4", "create tag color=#1a2b3g"} {
+		if _, err := Parse(bad); err == nil {
+			t.Fatalf("%s: expected error", bad)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package ast
 
 import (
 	"fmt"
-	"math"
 	"sort"
 	"strconv"
 )
@@ -15,30 +14,68 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	ruleScript
+	ruleWithBlock
+	ruleWithParams
 	ruleStatement
+	ruleInclude
+	ruleVarDeclaration
+	ruleVarAssignment
+	ruleGuard
+	ruleGuardOp
+	ruleGuardOperand
 	ruleAction
 	ruleEntity
 	ruleDeclaration
 	ruleExpr
 	ruleParams
 	ruleParam
+	ruleRegexMatch
+	ruleModifier
+	ruleFlag
 	ruleIdentifier
+	ruleName
+	ruleQuotedName
 	ruleValue
+	ruleItemValue
 	ruleStringValue
+	ruleListValue
+	ruleBracketListValue
+	ruleListItem
+	ruleSingleQuotedValue
+	ruleDoubleQuotedValue
+	ruleHeredocValue
+	ruleHeredocStart
+	ruleHeredocEnd
 	ruleCidrValue
 	ruleIpValue
 	ruleIntValue
+	ruleHexValue
+	ruleFloatValue
+	ruleExponent
 	ruleIntRangeValue
+	ruleRangeBound
+	ruleResourceIdValue
+	ruleHexOctet
+	ruleSizeValue
+	ruleColorValue
+	rulePercentOfValue
+	ruleNullValue
+	ruleArnValue
 	ruleRefValue
 	ruleAliasValue
+	ruleEnvValue
 	ruleHoleValue
 	ruleComment
+	ruleInlineComment
+	ruleBlockComment
+	ruleBlockCommentStart
 	ruleSpacing
 	ruleWhiteSpacing
 	ruleMustWhiteSpacing
 	ruleEqual
 	ruleSpace
 	ruleWhitespace
+	ruleLineContinuation
 	ruleEndOfLine
 	ruleEndOfFile
 	rulePegText
@@ -56,35 +93,106 @@ const (
 	ruleAction11
 	ruleAction12
 	ruleAction13
+	ruleAction14
+	ruleAction15
+	ruleAction16
+	ruleAction17
+	ruleAction18
+	ruleAction19
+	ruleAction20
+	ruleAction21
+	ruleAction22
+	ruleAction23
+	ruleAction24
+	ruleAction25
+	ruleAction26
+	ruleAction27
+	ruleAction28
+	ruleAction29
+	ruleAction30
+	ruleAction31
+	ruleAction32
+	ruleAction33
+	ruleAction34
+	ruleAction35
+	ruleAction36
+	ruleAction37
+	ruleAction38
+	ruleAction39
+	ruleAction40
+	ruleAction41
+	ruleAction42
+	ruleAction43
+	ruleAction44
+	ruleAction45
+	ruleAction46
 )
 
 var rul3s = [...]string{
 	"Unknown",
 	"Script",
+	"WithBlock",
+	"WithParams",
 	"Statement",
+	"Include",
+	"VarDeclaration",
+	"VarAssignment",
+	"Guard",
+	"GuardOp",
+	"GuardOperand",
 	"Action",
 	"Entity",
 	"Declaration",
 	"Expr",
 	"Params",
 	"Param",
+	"RegexMatch",
+	"Modifier",
+	"Flag",
 	"Identifier",
+	"Name",
+	"QuotedName",
 	"Value",
+	"ItemValue",
 	"StringValue",
+	"ListValue",
+	"BracketListValue",
+	"ListItem",
+	"SingleQuotedValue",
+	"DoubleQuotedValue",
+	"HeredocValue",
+	"HeredocStart",
+	"HeredocEnd",
 	"CidrValue",
 	"IpValue",
 	"IntValue",
+	"HexValue",
+	"FloatValue",
+	"Exponent",
 	"IntRangeValue",
+	"RangeBound",
+	"ResourceIdValue",
+	"HexOctet",
+	"SizeValue",
+	"ColorValue",
+	"PercentOfValue",
+	"NullValue",
+	"ArnValue",
 	"RefValue",
 	"AliasValue",
+	"EnvValue",
 	"HoleValue",
 	"Comment",
+	"InlineComment",
+	"BlockComment",
+	"BlockCommentStart",
 	"Spacing",
 	"WhiteSpacing",
 	"MustWhiteSpacing",
 	"Equal",
 	"Space",
 	"Whitespace",
+	"LineContinuation",
 	"EndOfLine",
 	"EndOfFile",
 	"PegText",
@@ -102,6 +210,39 @@ var rul3s = [...]string{
 	"Action11",
 	"Action12",
 	"Action13",
+	"Action14",
+	"Action15",
+	"Action16",
+	"Action17",
+	"Action18",
+	"Action19",
+	"Action20",
+	"Action21",
+	"Action22",
+	"Action23",
+	"Action24",
+	"Action25",
+	"Action26",
+	"Action27",
+	"Action28",
+	"Action29",
+	"Action30",
+	"Action31",
+	"Action32",
+	"Action33",
+	"Action34",
+	"Action35",
+	"Action36",
+	"Action37",
+	"Action38",
+	"Action39",
+	"Action40",
+	"Action41",
+	"Action42",
+	"Action43",
+	"Action44",
+	"Action45",
+	"Action46",
 }
 
 type token32 struct {
@@ -215,10 +356,12 @@ func (t *tokens32) Tokens() []token32 {
 
 type Peg struct {
 	*AST
+	done <-chan struct{}
+	parseState
 
 	Buffer string
 	buffer []rune
-	rules  [43]func() bool
+	rules  [114]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -311,32 +454,105 @@ func (p *Peg) Execute() {
 			text = string(_buffer[begin:end])
 
 		case ruleAction0:
-			p.AddDeclarationIdentifier(text)
+			p.StartWith()
+			p.markLine(begin)
 		case ruleAction1:
-			p.AddAction(text)
+			p.LineDone()
 		case ruleAction2:
-			p.AddEntity(text)
+			p.EndWith()
 		case ruleAction3:
+			p.AddInclude(text)
+			p.markLine(begin)
 			p.LineDone()
 		case ruleAction4:
-			p.AddParamKey(text)
+			p.AddVarIdentifier(text)
+			p.markLine(begin)
 		case ruleAction5:
-			p.AddParamHoleValue(text)
+			p.EndVar()
 		case ruleAction6:
-			p.AddParamAliasValue(text)
+			p.NegateGuard()
 		case ruleAction7:
-			p.AddParamRefValue(text)
+			p.AddGuardHole(text)
 		case ruleAction8:
-			p.AddParamCidrValue(text)
+			p.AddGuardOp(text)
 		case ruleAction9:
-			p.AddParamIpValue(text)
+			p.AddGuardOperand(text)
 		case ruleAction10:
-			p.AddParamValue(text)
+			p.AddGuardValue(text)
 		case ruleAction11:
-			p.AddParamIntValue(text)
+			p.AddDeclarationIdentifier(text)
+			p.markLine(begin)
 		case ruleAction12:
-			p.AddParamValue(text)
+			p.AddDeclarationExtraIdentifier(text)
 		case ruleAction13:
+			p.AddAction(text)
+			p.markLine(begin)
+		case ruleAction14:
+			p.AddEntity(text)
+		case ruleAction15:
+			p.LineDone()
+		case ruleAction16:
+			p.AddParamKey(text)
+		case ruleAction17:
+			p.AddParamRegexValue(text)
+		case ruleAction18:
+			p.AddModifier(text)
+		case ruleAction19:
+			p.AddFlag(text)
+		case ruleAction20:
+			p.AddParamHeredocValue(text)
+		case ruleAction21:
+			p.AddParamEnvValue(text)
+		case ruleAction22:
+			p.AddParamListValue(text)
+		case ruleAction23:
+			p.AddParamPercentOfValue(text)
+		case ruleAction24:
+			p.AddParamValue(text)
+		case ruleAction25:
+			p.AddParamQuotedValue(text)
+		case ruleAction26:
+			p.AddParamAliasValue(text)
+		case ruleAction27:
+			p.AddParamRefValue(text)
+		case ruleAction28:
+			p.AddParamCidrValue(text)
+		case ruleAction29:
+			p.AddParamIpValue(text)
+		case ruleAction30:
+			p.AddParamHexValue(text)
+		case ruleAction31:
+			p.AddParamIntRangeValue(text)
+		case ruleAction32:
+			p.AddParamSizeValue(text)
+		case ruleAction33:
+			p.AddParamColorValue(text)
+		case ruleAction34:
+			p.AddParamFloatValue(text)
+		case ruleAction35:
+			p.AddParamIntValue(text)
+		case ruleAction36:
+			p.AddParamArnValue(text)
+		case ruleAction37:
+			p.AddParamResourceIdValue(text)
+		case ruleAction38:
+			p.AddParamNullValue()
+		case ruleAction39:
+			p.AddParamValue(text)
+		case ruleAction40:
+			p.StartList()
+		case ruleAction41:
+			p.EndList()
+		case ruleAction42:
+			p.NextListItem()
+		case ruleAction43:
+			p.AddParamHoleValue(text)
+		case ruleAction44:
+			p.AddParamHoleType(text)
+		case ruleAction45:
+			p.AddComment(text)
+		case ruleAction46:
+			p.AddComment(text)
 			p.LineDone()
 
 		}
@@ -363,7 +579,7 @@ func (p *Peg) Init() {
 	p.reset()
 
 	_rules := p.rules
-	tree := tokens32{tree: make([]token32, math.MaxInt16)}
+	tree := tokens32{tree: make([]token32, 256)}
 	p.parse = func(rule ...int) error {
 		r := 1
 		if len(rule) > 0 {
@@ -412,7 +628,7 @@ func (p *Peg) Init() {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 Script <- <(Spacing Statement+ EndOfFile)> */
+		/* 0 Script <- <(Spacing (WithBlock / Statement)+ EndOfFile)> */
 		func() bool {
 			position0, tokenIndex0 := position, tokenIndex
 			{
@@ -421,258 +637,254 @@ func (p *Peg) Init() {
 					goto l0
 				}
 				{
-					position4 := position
-					if !_rules[ruleSpacing]() {
-						goto l0
-					}
+					position4, tokenIndex4 := position, tokenIndex
 					{
-						position5, tokenIndex5 := position, tokenIndex
-						if !_rules[ruleExpr]() {
-							goto l6
-						}
-						goto l5
-					l6:
-						position, tokenIndex = position5, tokenIndex5
+						position6 := position
 						{
-							position8 := position
-							{
-								position9 := position
-								if !_rules[ruleIdentifier]() {
-									goto l7
-								}
-								add(rulePegText, position9)
+							position7 := position
+							if buffer[position] != rune('w') {
+								goto l5
 							}
-							{
-								add(ruleAction0, position)
+							position++
+							if buffer[position] != rune('i') {
+								goto l5
 							}
-							if !_rules[ruleEqual]() {
-								goto l7
+							position++
+							if buffer[position] != rune('t') {
+								goto l5
 							}
-							if !_rules[ruleExpr]() {
-								goto l7
+							position++
+							if buffer[position] != rune('h') {
+								goto l5
 							}
-							add(ruleDeclaration, position8)
+							position++
+							add(rulePegText, position7)
 						}
-						goto l5
-					l7:
-						position, tokenIndex = position5, tokenIndex5
 						{
-							position11 := position
+							add(ruleAction0, position)
+						}
+						if !_rules[ruleMustWhiteSpacing]() {
+							goto l5
+						}
+						{
+							position9 := position
 							{
-								position12, tokenIndex12 := position, tokenIndex
-								if buffer[position] != rune('#') {
-									goto l13
+								position10 := position
+								if !_rules[ruleParam]() {
+									goto l5
 								}
-								position++
-							l14:
+							l11:
 								{
-									position15, tokenIndex15 := position, tokenIndex
-									{
-										position16, tokenIndex16 := position, tokenIndex
-										if !_rules[ruleEndOfLine]() {
-											goto l16
-										}
-										goto l15
-									l16:
-										position, tokenIndex = position16, tokenIndex16
+									position12, tokenIndex12 := position, tokenIndex
+									if !_rules[ruleParam]() {
+										goto l12
 									}
-									if !matchDot() {
-										goto l15
-									}
-									goto l14
-								l15:
-									position, tokenIndex = position15, tokenIndex15
+									goto l11
+								l12:
+									position, tokenIndex = position12, tokenIndex12
 								}
-								goto l12
-							l13:
-								position, tokenIndex = position12, tokenIndex12
-								if buffer[position] != rune('/') {
-									goto l0
+								add(ruleParams, position10)
+							}
+							add(ruleWithParams, position9)
+						}
+						{
+							add(ruleAction1, position)
+						}
+						if buffer[position] != rune('{') {
+							goto l5
+						}
+						position++
+						if !_rules[ruleSpacing]() {
+							goto l5
+						}
+					l14:
+						{
+							position15, tokenIndex15 := position, tokenIndex
+							if !_rules[ruleStatement]() {
+								goto l15
+							}
+							goto l14
+						l15:
+							position, tokenIndex = position15, tokenIndex15
+						}
+						if !_rules[ruleSpacing]() {
+							goto l5
+						}
+						if buffer[position] != rune('}') {
+							goto l5
+						}
+						position++
+						{
+							add(ruleAction2, position)
+						}
+						if !_rules[ruleSpacing]() {
+							goto l5
+						}
+					l17:
+						{
+							position18, tokenIndex18 := position, tokenIndex
+							{
+								position19, tokenIndex19 := position, tokenIndex
+								if !_rules[ruleEndOfLine]() {
+									goto l20
 								}
-								position++
-								if buffer[position] != rune('/') {
-									goto l0
+								goto l19
+							l20:
+								position, tokenIndex = position19, tokenIndex19
+								if buffer[position] != rune(';') {
+									goto l18
 								}
 								position++
-							l17:
-								{
-									position18, tokenIndex18 := position, tokenIndex
-									{
-										position19, tokenIndex19 := position, tokenIndex
-										if !_rules[ruleEndOfLine]() {
-											goto l19
-										}
-										goto l18
-									l19:
-										position, tokenIndex = position19, tokenIndex19
-									}
-									if !matchDot() {
-										goto l18
-									}
-									goto l17
-								l18:
-									position, tokenIndex = position18, tokenIndex18
-								}
-								{
-									add(ruleAction13, position)
-								}
 							}
-						l12:
-							add(ruleComment, position11)
+						l19:
+							goto l17
+						l18:
+							position, tokenIndex = position18, tokenIndex18
 						}
+						add(ruleWithBlock, position6)
 					}
+					goto l4
 				l5:
-					if !_rules[ruleSpacing]() {
+					position, tokenIndex = position4, tokenIndex4
+					if !_rules[ruleStatement]() {
 						goto l0
 					}
-				l21:
-					{
-						position22, tokenIndex22 := position, tokenIndex
-						if !_rules[ruleEndOfLine]() {
-							goto l22
-						}
-						goto l21
-					l22:
-						position, tokenIndex = position22, tokenIndex22
-					}
-					add(ruleStatement, position4)
 				}
+			l4:
 			l2:
 				{
 					position3, tokenIndex3 := position, tokenIndex
 					{
-						position23 := position
-						if !_rules[ruleSpacing]() {
-							goto l3
-						}
+						position21, tokenIndex21 := position, tokenIndex
 						{
-							position24, tokenIndex24 := position, tokenIndex
-							if !_rules[ruleExpr]() {
-								goto l25
-							}
-							goto l24
-						l25:
-							position, tokenIndex = position24, tokenIndex24
+							position23 := position
 							{
-								position27 := position
-								{
-									position28 := position
-									if !_rules[ruleIdentifier]() {
-										goto l26
-									}
-									add(rulePegText, position28)
+								position24 := position
+								if buffer[position] != rune('w') {
+									goto l22
 								}
-								{
-									add(ruleAction0, position)
+								position++
+								if buffer[position] != rune('i') {
+									goto l22
 								}
-								if !_rules[ruleEqual]() {
-									goto l26
+								position++
+								if buffer[position] != rune('t') {
+									goto l22
 								}
-								if !_rules[ruleExpr]() {
-									goto l26
+								position++
+								if buffer[position] != rune('h') {
+									goto l22
 								}
-								add(ruleDeclaration, position27)
+								position++
+								add(rulePegText, position24)
+							}
+							{
+								add(ruleAction0, position)
+							}
+							if !_rules[ruleMustWhiteSpacing]() {
+								goto l22
 							}
-							goto l24
-						l26:
-							position, tokenIndex = position24, tokenIndex24
 							{
-								position30 := position
+								position26 := position
 								{
-									position31, tokenIndex31 := position, tokenIndex
-									if buffer[position] != rune('#') {
-										goto l32
+									position27 := position
+									if !_rules[ruleParam]() {
+										goto l22
 									}
-									position++
-								l33:
+								l28:
 									{
-										position34, tokenIndex34 := position, tokenIndex
-										{
-											position35, tokenIndex35 := position, tokenIndex
-											if !_rules[ruleEndOfLine]() {
-												goto l35
-											}
-											goto l34
-										l35:
-											position, tokenIndex = position35, tokenIndex35
-										}
-										if !matchDot() {
-											goto l34
+										position29, tokenIndex29 := position, tokenIndex
+										if !_rules[ruleParam]() {
+											goto l29
 										}
-										goto l33
-									l34:
-										position, tokenIndex = position34, tokenIndex34
+										goto l28
+									l29:
+										position, tokenIndex = position29, tokenIndex29
 									}
-									goto l31
-								l32:
-									position, tokenIndex = position31, tokenIndex31
-									if buffer[position] != rune('/') {
-										goto l3
+									add(ruleParams, position27)
+								}
+								add(ruleWithParams, position26)
+							}
+							{
+								add(ruleAction1, position)
+							}
+							if buffer[position] != rune('{') {
+								goto l22
+							}
+							position++
+							if !_rules[ruleSpacing]() {
+								goto l22
+							}
+						l31:
+							{
+								position32, tokenIndex32 := position, tokenIndex
+								if !_rules[ruleStatement]() {
+									goto l32
+								}
+								goto l31
+							l32:
+								position, tokenIndex = position32, tokenIndex32
+							}
+							if !_rules[ruleSpacing]() {
+								goto l22
+							}
+							if buffer[position] != rune('}') {
+								goto l22
+							}
+							position++
+							{
+								add(ruleAction2, position)
+							}
+							if !_rules[ruleSpacing]() {
+								goto l22
+							}
+						l34:
+							{
+								position35, tokenIndex35 := position, tokenIndex
+								{
+									position36, tokenIndex36 := position, tokenIndex
+									if !_rules[ruleEndOfLine]() {
+										goto l37
 									}
-									position++
-									if buffer[position] != rune('/') {
-										goto l3
+									goto l36
+								l37:
+									position, tokenIndex = position36, tokenIndex36
+									if buffer[position] != rune(';') {
+										goto l35
 									}
 									position++
-								l36:
-									{
-										position37, tokenIndex37 := position, tokenIndex
-										{
-											position38, tokenIndex38 := position, tokenIndex
-											if !_rules[ruleEndOfLine]() {
-												goto l38
-											}
-											goto l37
-										l38:
-											position, tokenIndex = position38, tokenIndex38
-										}
-										if !matchDot() {
-											goto l37
-										}
-										goto l36
-									l37:
-										position, tokenIndex = position37, tokenIndex37
-									}
-									{
-										add(ruleAction13, position)
-									}
 								}
-							l31:
-								add(ruleComment, position30)
+							l36:
+								goto l34
+							l35:
+								position, tokenIndex = position35, tokenIndex35
 							}
+							add(ruleWithBlock, position23)
 						}
-					l24:
-						if !_rules[ruleSpacing]() {
+						goto l21
+					l22:
+						position, tokenIndex = position21, tokenIndex21
+						if !_rules[ruleStatement]() {
 							goto l3
 						}
-					l40:
-						{
-							position41, tokenIndex41 := position, tokenIndex
-							if !_rules[ruleEndOfLine]() {
-								goto l41
-							}
-							goto l40
-						l41:
-							position, tokenIndex = position41, tokenIndex41
-						}
-						add(ruleStatement, position23)
 					}
+				l21:
 					goto l2
 				l3:
 					position, tokenIndex = position3, tokenIndex3
 				}
 				{
-					position42 := position
+					position38 := position
 					{
-						position43, tokenIndex43 := position, tokenIndex
+						position39, tokenIndex39 := position, tokenIndex
 						if !matchDot() {
-							goto l43
+							goto l39
 						}
 						goto l0
-					l43:
-						position, tokenIndex = position43, tokenIndex43
+					l39:
+						position, tokenIndex = position39, tokenIndex39
 					}
-					add(ruleEndOfFile, position42)
+					add(ruleEndOfFile, position38)
 				}
 				add(ruleScript, position1)
 			}
@@ -681,2075 +893,4911 @@ func (p *Peg) Init() {
 			position, tokenIndex = position0, tokenIndex0
 			return false
 		},
-		/* 1 Statement <- <(Spacing (Expr / Declaration / Comment) Spacing EndOfLine*)> */
-		nil,
-		/* 2 Action <- <(('c' 'r' 'e' 'a' 't' 'e') / ('d' 'e' 'l' 'e' 't' 'e') / ('s' 't' 'a' 'r' 't') / ((&('d') ('d' 'e' 't' 'a' 'c' 'h')) | (&('c') ('c' 'h' 'e' 'c' 'k')) | (&('a') ('a' 't' 't' 'a' 'c' 'h')) | (&('u') ('u' 'p' 'd' 'a' 't' 'e')) | (&('s') ('s' 't' 'o' 'p'))))> */
+		/* 1 WithBlock <- <(<('w' 'i' 't' 'h')> Action0 MustWhiteSpacing WithParams Action1 '{' Spacing Statement* Spacing '}' Action2 Spacing (EndOfLine / ';')*)> */
 		nil,
-		/* 3 Entity <- <(('v' 'p' 'c') / ('s' 'u' 'b' 'n' 'e' 't') / ('i' 'n' 's' 't' 'a' 'n' 'c' 'e') / ('r' 'o' 'l' 'e') / ('s' 'e' 'c' 'u' 'r' 'i' 't' 'y' 'g' 'r' 'o' 'u' 'p') / ('r' 'o' 'u' 't' 'e' 't' 'a' 'b' 'l' 'e') / ((&('s') ('s' 't' 'o' 'r' 'a' 'g' 'e' 'o' 'b' 'j' 'e' 'c' 't')) | (&('b') ('b' 'u' 'c' 'k' 'e' 't')) | (&('r') ('r' 'o' 'u' 't' 'e')) | (&('i') ('i' 'n' 't' 'e' 'r' 'n' 'e' 't' 'g' 'a' 't' 'e' 'w' 'a' 'y')) | (&('k') ('k' 'e' 'y' 'p' 'a' 'i' 'r')) | (&('p') ('p' 'o' 'l' 'i' 'c' 'y')) | (&('g') ('g' 'r' 'o' 'u' 'p')) | (&('u') ('u' 's' 'e' 'r')) | (&('t') ('t' 'a' 'g' 's')) | (&('v') ('v' 'o' 'l' 'u' 'm' 'e'))))> */
+		/* 2 WithParams <- <Params> */
 		nil,
-		/* 4 Declaration <- <(<Identifier> Action0 Equal Expr)> */
-		nil,
-		/* 5 Expr <- <(<Action> Action1 MustWhiteSpacing <Entity> Action2 (MustWhiteSpacing Params)? Action3)> */
+		/* 3 Statement <- <(&{ p.alive() } Spacing (((Include / VarDeclaration / (Guard? (Expr / Declaration))) WhiteSpacing InlineComment?) / Comment) Spacing (EndOfLine / ';')*)> */
 		func() bool {
-			position48, tokenIndex48 := position, tokenIndex
+			position42, tokenIndex42 := position, tokenIndex
 			{
-				position49 := position
+				position43 := position
+				if !(p.alive()) {
+					goto l42
+				}
+				if !_rules[ruleSpacing]() {
+					goto l42
+				}
 				{
-					position50 := position
+					position44, tokenIndex44 := position, tokenIndex
 					{
-						position51 := position
+						position46, tokenIndex46 := position, tokenIndex
 						{
-							position52, tokenIndex52 := position, tokenIndex
-							if buffer[position] != rune('c') {
-								goto l53
-							}
-							position++
-							if buffer[position] != rune('r') {
-								goto l53
+							position48 := position
+							if buffer[position] != rune('i') {
+								goto l47
 							}
 							position++
-							if buffer[position] != rune('e') {
-								goto l53
+							if buffer[position] != rune('n') {
+								goto l47
 							}
 							position++
-							if buffer[position] != rune('a') {
-								goto l53
+							if buffer[position] != rune('c') {
+								goto l47
 							}
 							position++
-							if buffer[position] != rune('t') {
-								goto l53
+							if buffer[position] != rune('l') {
+								goto l47
 							}
 							position++
-							if buffer[position] != rune('e') {
-								goto l53
+							if buffer[position] != rune('u') {
+								goto l47
 							}
 							position++
-							goto l52
-						l53:
-							position, tokenIndex = position52, tokenIndex52
 							if buffer[position] != rune('d') {
-								goto l54
-							}
-							position++
-							if buffer[position] != rune('e') {
-								goto l54
-							}
-							position++
-							if buffer[position] != rune('l') {
-								goto l54
+								goto l47
 							}
 							position++
 							if buffer[position] != rune('e') {
-								goto l54
+								goto l47
 							}
 							position++
-							if buffer[position] != rune('t') {
-								goto l54
+							if !_rules[ruleMustWhiteSpacing]() {
+								goto l47
 							}
-							position++
-							if buffer[position] != rune('e') {
-								goto l54
+							if !_rules[ruleDoubleQuotedValue]() {
+								goto l47
 							}
-							position++
-							goto l52
-						l54:
-							position, tokenIndex = position52, tokenIndex52
-							if buffer[position] != rune('s') {
-								goto l55
+							{
+								add(ruleAction3, position)
 							}
-							position++
-							if buffer[position] != rune('t') {
-								goto l55
+							add(ruleInclude, position48)
+						}
+						goto l46
+					l47:
+						position, tokenIndex = position46, tokenIndex46
+						{
+							position51 := position
+							if buffer[position] != rune('v') {
+								goto l50
 							}
 							position++
 							if buffer[position] != rune('a') {
-								goto l55
+								goto l50
 							}
 							position++
 							if buffer[position] != rune('r') {
-								goto l55
+								goto l50
 							}
 							position++
-							if buffer[position] != rune('t') {
-								goto l55
+							if !_rules[ruleMustWhiteSpacing]() {
+								goto l50
 							}
-							position++
-							goto l52
-						l55:
-							position, tokenIndex = position52, tokenIndex52
+							if !_rules[ruleVarAssignment]() {
+								goto l50
+							}
+						l52:
 							{
-								switch buffer[position] {
-								case 'd':
-									if buffer[position] != rune('d') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('t') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('a') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('c') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('h') {
-										goto l48
-									}
-									position++
-									break
-								case 'c':
-									if buffer[position] != rune('c') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('h') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
+								position53, tokenIndex53 := position, tokenIndex
+								if !_rules[ruleWhiteSpacing]() {
+									goto l53
+								}
+								if buffer[position] != rune(',') {
+									goto l53
+								}
+								position++
+								if !_rules[ruleWhiteSpacing]() {
+									goto l53
+								}
+								if !_rules[ruleVarAssignment]() {
+									goto l53
+								}
+								goto l52
+							l53:
+								position, tokenIndex = position53, tokenIndex53
+							}
+							add(ruleVarDeclaration, position51)
+						}
+						goto l46
+					l50:
+						position, tokenIndex = position46, tokenIndex46
+						{
+							position54, tokenIndex54 := position, tokenIndex
+							{
+								position56 := position
+								if buffer[position] != rune('w') {
+									goto l54
+								}
+								position++
+								if buffer[position] != rune('h') {
+									goto l54
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l54
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l54
+								}
+								position++
+								if !_rules[ruleMustWhiteSpacing]() {
+									goto l54
+								}
+								{
+									position57, tokenIndex57 := position, tokenIndex
+									if buffer[position] != rune('!') {
+										goto l57
 									}
 									position++
-									if buffer[position] != rune('c') {
-										goto l48
+									if !_rules[ruleWhiteSpacing]() {
+										goto l57
 									}
-									position++
-									if buffer[position] != rune('k') {
-										goto l48
+									{
+										add(ruleAction6, position)
 									}
-									position++
-									break
-								case 'a':
-									if buffer[position] != rune('a') {
-										goto l48
+									goto l58
+								l57:
+									position, tokenIndex = position57, tokenIndex57
+								}
+							l58:
+								{
+									position60, tokenIndex60 := position, tokenIndex
+									if buffer[position] != rune('{') {
+										goto l61
 									}
 									position++
-									if buffer[position] != rune('t') {
-										goto l48
+									if !_rules[ruleWhiteSpacing]() {
+										goto l61
 									}
-									position++
-									if buffer[position] != rune('t') {
-										goto l48
+									{
+										position62 := position
+										if !_rules[ruleName]() {
+											goto l61
+										}
+										add(rulePegText, position62)
 									}
-									position++
-									if buffer[position] != rune('a') {
-										goto l48
+									{
+										add(ruleAction7, position)
 									}
-									position++
-									if buffer[position] != rune('c') {
-										goto l48
+									if !_rules[ruleWhiteSpacing]() {
+										goto l61
 									}
-									position++
-									if buffer[position] != rune('h') {
-										goto l48
+									if buffer[position] != rune('}') {
+										goto l61
 									}
 									position++
-									break
-								case 'u':
-									if buffer[position] != rune('u') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('p') {
-										goto l48
+									{
+										position64, tokenIndex64 := position, tokenIndex
+										if !_rules[ruleWhiteSpacing]() {
+											goto l64
+										}
+										{
+											position66 := position
+											{
+												position67 := position
+												{
+													position68, tokenIndex68 := position, tokenIndex
+													if buffer[position] != rune('<') {
+														goto l69
+													}
+													position++
+													if buffer[position] != rune('=') {
+														goto l69
+													}
+													position++
+													goto l68
+												l69:
+													position, tokenIndex = position68, tokenIndex68
+													if buffer[position] != rune('>') {
+														goto l70
+													}
+													position++
+													if buffer[position] != rune('=') {
+														goto l70
+													}
+													position++
+													goto l68
+												l70:
+													position, tokenIndex = position68, tokenIndex68
+													{
+														switch buffer[position] {
+														case '>':
+															if buffer[position] != rune('>') {
+																goto l64
+															}
+															position++
+															break
+														case '<':
+															if buffer[position] != rune('<') {
+																goto l64
+															}
+															position++
+															break
+														case '!':
+															if buffer[position] != rune('!') {
+																goto l64
+															}
+															position++
+															if buffer[position] != rune('=') {
+																goto l64
+															}
+															position++
+															break
+														default:
+															if buffer[position] != rune('=') {
+																goto l64
+															}
+															position++
+															if buffer[position] != rune('=') {
+																goto l64
+															}
+															position++
+															break
+														}
+													}
+
+												}
+											l68:
+												add(ruleGuardOp, position67)
+											}
+											add(rulePegText, position66)
+										}
+										{
+											add(ruleAction8, position)
+										}
+										if !_rules[ruleWhiteSpacing]() {
+											goto l64
+										}
+										{
+											position73 := position
+											{
+												position74 := position
+												{
+													switch buffer[position] {
+													case '\'':
+														if buffer[position] != rune('\'') {
+															goto l64
+														}
+														position++
+													l76:
+														{
+															position77, tokenIndex77 := position, tokenIndex
+															{
+																position78, tokenIndex78 := position, tokenIndex
+																if buffer[position] != rune('\'') {
+																	goto l78
+																}
+																position++
+																goto l77
+															l78:
+																position, tokenIndex = position78, tokenIndex78
+															}
+															if !matchDot() {
+																goto l77
+															}
+															goto l76
+														l77:
+															position, tokenIndex = position77, tokenIndex77
+														}
+														if buffer[position] != rune('\'') {
+															goto l64
+														}
+														position++
+														break
+													case 'f', 't':
+														{
+															position79, tokenIndex79 := position, tokenIndex
+															if buffer[position] != rune('t') {
+																goto l80
+															}
+															position++
+															if buffer[position] != rune('r') {
+																goto l80
+															}
+															position++
+															if buffer[position] != rune('u') {
+																goto l80
+															}
+															position++
+															if buffer[position] != rune('e') {
+																goto l80
+															}
+															position++
+															goto l79
+														l80:
+															position, tokenIndex = position79, tokenIndex79
+															if buffer[position] != rune('f') {
+																goto l64
+															}
+															position++
+															if buffer[position] != rune('a') {
+																goto l64
+															}
+															position++
+															if buffer[position] != rune('l') {
+																goto l64
+															}
+															position++
+															if buffer[position] != rune('s') {
+																goto l64
+															}
+															position++
+															if buffer[position] != rune('e') {
+																goto l64
+															}
+															position++
+														}
+													l79:
+														{
+															position81, tokenIndex81 := position, tokenIndex
+															{
+																switch buffer[position] {
+																case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+																	if c := buffer[position]; c < rune('0') || c > rune('9') {
+																		goto l81
+																	}
+																	position++
+																	break
+																case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+																	if c := buffer[position]; c < rune('A') || c > rune('Z') {
+																		goto l81
+																	}
+																	position++
+																	break
+																default:
+																	if c := buffer[position]; c < rune('a') || c > rune('z') {
+																		goto l81
+																	}
+																	position++
+																	break
+																}
+															}
+
+															goto l64
+														l81:
+															position, tokenIndex = position81, tokenIndex81
+														}
+														break
+													default:
+														{
+															position83, tokenIndex83 := position, tokenIndex
+															if buffer[position] != rune('-') {
+																goto l83
+															}
+															position++
+															goto l84
+														l83:
+															position, tokenIndex = position83, tokenIndex83
+														}
+													l84:
+														if c := buffer[position]; c < rune('0') || c > rune('9') {
+															goto l64
+														}
+														position++
+													l85:
+														{
+															position86, tokenIndex86 := position, tokenIndex
+															if c := buffer[position]; c < rune('0') || c > rune('9') {
+																goto l86
+															}
+															position++
+															goto l85
+														l86:
+															position, tokenIndex = position86, tokenIndex86
+														}
+														{
+															position87, tokenIndex87 := position, tokenIndex
+															if buffer[position] != rune('.') {
+																goto l87
+															}
+															position++
+															if c := buffer[position]; c < rune('0') || c > rune('9') {
+																goto l87
+															}
+															position++
+														l89:
+															{
+																position90, tokenIndex90 := position, tokenIndex
+																if c := buffer[position]; c < rune('0') || c > rune('9') {
+																	goto l90
+																}
+																position++
+																goto l89
+															l90:
+																position, tokenIndex = position90, tokenIndex90
+															}
+															goto l88
+														l87:
+															position, tokenIndex = position87, tokenIndex87
+														}
+													l88:
+														{
+															position91, tokenIndex91 := position, tokenIndex
+															{
+																switch buffer[position] {
+																case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+																	if c := buffer[position]; c < rune('0') || c > rune('9') {
+																		goto l91
+																	}
+																	position++
+																	break
+																case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+																	if c := buffer[position]; c < rune('A') || c > rune('Z') {
+																		goto l91
+																	}
+																	position++
+																	break
+																default:
+																	if c := buffer[position]; c < rune('a') || c > rune('z') {
+																		goto l91
+																	}
+																	position++
+																	break
+																}
+															}
+
+															goto l64
+														l91:
+															position, tokenIndex = position91, tokenIndex91
+														}
+														break
+													}
+												}
+
+												add(ruleGuardOperand, position74)
+											}
+											add(rulePegText, position73)
+										}
+										{
+											add(ruleAction9, position)
+										}
+										goto l65
+									l64:
+										position, tokenIndex = position64, tokenIndex64
 									}
-									position++
-									if buffer[position] != rune('d') {
-										goto l48
+								l65:
+									goto l60
+								l61:
+									position, tokenIndex = position60, tokenIndex60
+									{
+										position94 := position
+										{
+											position95, tokenIndex95 := position, tokenIndex
+											if buffer[position] != rune('t') {
+												goto l96
+											}
+											position++
+											if buffer[position] != rune('r') {
+												goto l96
+											}
+											position++
+											if buffer[position] != rune('u') {
+												goto l96
+											}
+											position++
+											if buffer[position] != rune('e') {
+												goto l96
+											}
+											position++
+											goto l95
+										l96:
+											position, tokenIndex = position95, tokenIndex95
+											if buffer[position] != rune('f') {
+												goto l54
+											}
+											position++
+											if buffer[position] != rune('a') {
+												goto l54
+											}
+											position++
+											if buffer[position] != rune('l') {
+												goto l54
+											}
+											position++
+											if buffer[position] != rune('s') {
+												goto l54
+											}
+											position++
+											if buffer[position] != rune('e') {
+												goto l54
+											}
+											position++
+										}
+									l95:
+										add(rulePegText, position94)
 									}
-									position++
-									if buffer[position] != rune('a') {
-										goto l48
+									{
+										add(ruleAction10, position)
 									}
-									position++
-									if buffer[position] != rune('t') {
-										goto l48
+								}
+							l60:
+								if !_rules[ruleMustWhiteSpacing]() {
+									goto l54
+								}
+								add(ruleGuard, position56)
+							}
+							goto l55
+						l54:
+							position, tokenIndex = position54, tokenIndex54
+						}
+					l55:
+						{
+							position98, tokenIndex98 := position, tokenIndex
+							if !_rules[ruleExpr]() {
+								goto l99
+							}
+							goto l98
+						l99:
+							position, tokenIndex = position98, tokenIndex98
+							{
+								position100 := position
+								{
+									position101 := position
+									{
+										position102, tokenIndex102 := position, tokenIndex
+										if !_rules[ruleName]() {
+											goto l103
+										}
+										goto l102
+									l103:
+										position, tokenIndex = position102, tokenIndex102
+										if !_rules[ruleQuotedName]() {
+											goto l45
+										}
 									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
+								l102:
+									add(rulePegText, position101)
+								}
+								{
+									add(ruleAction11, position)
+								}
+							l105:
+								{
+									position106, tokenIndex106 := position, tokenIndex
+									if !_rules[ruleWhiteSpacing]() {
+										goto l106
 									}
-									position++
-									break
-								default:
-									if buffer[position] != rune('s') {
-										goto l48
+									if buffer[position] != rune(',') {
+										goto l106
 									}
 									position++
-									if buffer[position] != rune('t') {
-										goto l48
+									if !_rules[ruleWhiteSpacing]() {
+										goto l106
 									}
-									position++
-									if buffer[position] != rune('o') {
-										goto l48
+									{
+										position107 := position
+										{
+											position108, tokenIndex108 := position, tokenIndex
+											if !_rules[ruleName]() {
+												goto l109
+											}
+											goto l108
+										l109:
+											position, tokenIndex = position108, tokenIndex108
+											if !_rules[ruleQuotedName]() {
+												goto l106
+											}
+										}
+									l108:
+										add(rulePegText, position107)
 									}
-									position++
-									if buffer[position] != rune('p') {
-										goto l48
+									{
+										add(ruleAction12, position)
 									}
-									position++
-									break
+									goto l105
+								l106:
+									position, tokenIndex = position106, tokenIndex106
+								}
+								if !_rules[ruleEqual]() {
+									goto l45
 								}
+								if !_rules[ruleExpr]() {
+									goto l45
+								}
+								add(ruleDeclaration, position100)
 							}
-
 						}
-					l52:
-						add(ruleAction, position51)
+					l98:
+					}
+				l46:
+					if !_rules[ruleWhiteSpacing]() {
+						goto l45
 					}
-					add(rulePegText, position50)
-				}
-				{
-					add(ruleAction1, position)
-				}
-				if !_rules[ruleMustWhiteSpacing]() {
-					goto l48
-				}
-				{
-					position58 := position
 					{
-						position59 := position
+						position111, tokenIndex111 := position, tokenIndex
 						{
-							position60, tokenIndex60 := position, tokenIndex
-							if buffer[position] != rune('v') {
-								goto l61
-							}
-							position++
-							if buffer[position] != rune('p') {
-								goto l61
-							}
-							position++
-							if buffer[position] != rune('c') {
-								goto l61
-							}
-							position++
-							goto l60
-						l61:
-							position, tokenIndex = position60, tokenIndex60
-							if buffer[position] != rune('s') {
-								goto l62
-							}
-							position++
-							if buffer[position] != rune('u') {
-								goto l62
-							}
-							position++
-							if buffer[position] != rune('b') {
-								goto l62
-							}
-							position++
-							if buffer[position] != rune('n') {
-								goto l62
-							}
-							position++
-							if buffer[position] != rune('e') {
-								goto l62
-							}
-							position++
-							if buffer[position] != rune('t') {
-								goto l62
+							position113 := position
+							{
+								position114, tokenIndex114 := position, tokenIndex
+								if buffer[position] != rune('#') {
+									goto l115
+								}
+								position++
+							l116:
+								{
+									position117, tokenIndex117 := position, tokenIndex
+									{
+										position118, tokenIndex118 := position, tokenIndex
+										if !_rules[ruleEndOfLine]() {
+											goto l118
+										}
+										goto l117
+									l118:
+										position, tokenIndex = position118, tokenIndex118
+									}
+									if !matchDot() {
+										goto l117
+									}
+									goto l116
+								l117:
+									position, tokenIndex = position117, tokenIndex117
+								}
+								goto l114
+							l115:
+								position, tokenIndex = position114, tokenIndex114
+								if buffer[position] != rune('/') {
+									goto l111
+								}
+								position++
+								if buffer[position] != rune('/') {
+									goto l111
+								}
+								position++
+							l119:
+								{
+									position120, tokenIndex120 := position, tokenIndex
+									{
+										position121, tokenIndex121 := position, tokenIndex
+										if !_rules[ruleEndOfLine]() {
+											goto l121
+										}
+										goto l120
+									l121:
+										position, tokenIndex = position121, tokenIndex121
+									}
+									if !matchDot() {
+										goto l120
+									}
+									goto l119
+								l120:
+									position, tokenIndex = position120, tokenIndex120
+								}
 							}
-							position++
-							goto l60
-						l62:
-							position, tokenIndex = position60, tokenIndex60
-							if buffer[position] != rune('i') {
-								goto l63
+						l114:
+							add(ruleInlineComment, position113)
+						}
+						goto l112
+					l111:
+						position, tokenIndex = position111, tokenIndex111
+					}
+				l112:
+					goto l44
+				l45:
+					position, tokenIndex = position44, tokenIndex44
+					{
+						position122 := position
+						{
+							position123, tokenIndex123 := position, tokenIndex
+							{
+								position125 := position
+								if buffer[position] != rune('#') {
+									goto l124
+								}
+								position++
+							l126:
+								{
+									position127, tokenIndex127 := position, tokenIndex
+									{
+										position128, tokenIndex128 := position, tokenIndex
+										if !_rules[ruleEndOfLine]() {
+											goto l128
+										}
+										goto l127
+									l128:
+										position, tokenIndex = position128, tokenIndex128
+									}
+									if !matchDot() {
+										goto l127
+									}
+									goto l126
+								l127:
+									position, tokenIndex = position127, tokenIndex127
+								}
+								add(rulePegText, position125)
 							}
-							position++
-							if buffer[position] != rune('n') {
-								goto l63
+							{
+								add(ruleAction45, position)
 							}
-							position++
-							if buffer[position] != rune('s') {
-								goto l63
+							goto l123
+						l124:
+							position, tokenIndex = position123, tokenIndex123
+							{
+								position131 := position
+								if buffer[position] != rune('/') {
+									goto l130
+								}
+								position++
+								if buffer[position] != rune('/') {
+									goto l130
+								}
+								position++
+							l132:
+								{
+									position133, tokenIndex133 := position, tokenIndex
+									{
+										position134, tokenIndex134 := position, tokenIndex
+										if !_rules[ruleEndOfLine]() {
+											goto l134
+										}
+										goto l133
+									l134:
+										position, tokenIndex = position134, tokenIndex134
+									}
+									if !matchDot() {
+										goto l133
+									}
+									goto l132
+								l133:
+									position, tokenIndex = position133, tokenIndex133
+								}
+								add(rulePegText, position131)
 							}
-							position++
-							if buffer[position] != rune('t') {
-								goto l63
+							{
+								add(ruleAction46, position)
 							}
-							position++
-							if buffer[position] != rune('a') {
-								goto l63
+							goto l123
+						l130:
+							position, tokenIndex = position123, tokenIndex123
+							{
+								position136 := position
+								{
+									position137 := position
+									if buffer[position] != rune('/') {
+										goto l42
+									}
+									position++
+									if buffer[position] != rune('*') {
+										goto l42
+									}
+									position++
+									add(ruleBlockCommentStart, position137)
+								}
+							l138:
+								{
+									position139, tokenIndex139 := position, tokenIndex
+									{
+										position140, tokenIndex140 := position, tokenIndex
+										if buffer[position] != rune('*') {
+											goto l140
+										}
+										position++
+										if buffer[position] != rune('/') {
+											goto l140
+										}
+										position++
+										goto l139
+									l140:
+										position, tokenIndex = position140, tokenIndex140
+									}
+									if !matchDot() {
+										goto l139
+									}
+									goto l138
+								l139:
+									position, tokenIndex = position139, tokenIndex139
+								}
+								if buffer[position] != rune('*') {
+									goto l42
+								}
+								position++
+								if buffer[position] != rune('/') {
+									goto l42
+								}
+								position++
+								add(ruleBlockComment, position136)
 							}
-							position++
-							if buffer[position] != rune('n') {
-								goto l63
+						}
+					l123:
+						add(ruleComment, position122)
+					}
+				}
+			l44:
+				if !_rules[ruleSpacing]() {
+					goto l42
+				}
+			l141:
+				{
+					position142, tokenIndex142 := position, tokenIndex
+					{
+						position143, tokenIndex143 := position, tokenIndex
+						if !_rules[ruleEndOfLine]() {
+							goto l144
+						}
+						goto l143
+					l144:
+						position, tokenIndex = position143, tokenIndex143
+						if buffer[position] != rune(';') {
+							goto l142
+						}
+						position++
+					}
+				l143:
+					goto l141
+				l142:
+					position, tokenIndex = position142, tokenIndex142
+				}
+				add(ruleStatement, position43)
+			}
+			return true
+		l42:
+			position, tokenIndex = position42, tokenIndex42
+			return false
+		},
+		/* 4 Include <- <('i' 'n' 'c' 'l' 'u' 'd' 'e' MustWhiteSpacing DoubleQuotedValue Action3)> */
+		nil,
+		/* 5 VarDeclaration <- <('v' 'a' 'r' MustWhiteSpacing VarAssignment (WhiteSpacing ',' WhiteSpacing VarAssignment)*)> */
+		nil,
+		/* 6 VarAssignment <- <(<(Name / QuotedName)> Action4 Equal Value Action5)> */
+		func() bool {
+			position147, tokenIndex147 := position, tokenIndex
+			{
+				position148 := position
+				{
+					position149 := position
+					{
+						position150, tokenIndex150 := position, tokenIndex
+						if !_rules[ruleName]() {
+							goto l151
+						}
+						goto l150
+					l151:
+						position, tokenIndex = position150, tokenIndex150
+						if !_rules[ruleQuotedName]() {
+							goto l147
+						}
+					}
+				l150:
+					add(rulePegText, position149)
+				}
+				{
+					add(ruleAction4, position)
+				}
+				if !_rules[ruleEqual]() {
+					goto l147
+				}
+				if !_rules[ruleValue]() {
+					goto l147
+				}
+				{
+					add(ruleAction5, position)
+				}
+				add(ruleVarAssignment, position148)
+			}
+			return true
+		l147:
+			position, tokenIndex = position147, tokenIndex147
+			return false
+		},
+		/* 7 Guard <- <('w' 'h' 'e' 'n' MustWhiteSpacing ('!' WhiteSpacing Action6)? (('{' WhiteSpacing <Name> Action7 WhiteSpacing '}' (WhiteSpacing <GuardOp> Action8 WhiteSpacing <GuardOperand> Action9)?) / (<(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> Action10)) MustWhiteSpacing)> */
+		nil,
+		/* 8 GuardOp <- <(('<' '=') / ('>' '=') / ((&('>') '>') | (&('<') '<') | (&('!') ('!' '=')) | (&('=') ('=' '='))))> */
+		nil,
+		/* 9 GuardOperand <- <((&('\'') ('\'' (!'\'' .)* '\'')) | (&('f' | 't') ((('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e')) !((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))) | (&('-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') ('-'? [0-9]+ ('.' [0-9]+)? !((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))))> */
+		nil,
+		/* 10 Action <- <[a-z]+> */
+		nil,
+		/* 11 Entity <- <Identifier> */
+		nil,
+		/* 12 Declaration <- <(<(Name / QuotedName)> Action11 (WhiteSpacing ',' WhiteSpacing <(Name / QuotedName)> Action12)* Equal Expr)> */
+		nil,
+		/* 13 Expr <- <(<Action> Action13 MustWhiteSpacing <Entity> Action14 (MustWhiteSpacing ((Flag WhiteSpacing) / (Modifier WhiteSpacing) / Param)+)? Action15)> */
+		func() bool {
+			position160, tokenIndex160 := position, tokenIndex
+			{
+				position161 := position
+				{
+					position162 := position
+					{
+						position163 := position
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l160
+						}
+						position++
+					l164:
+						{
+							position165, tokenIndex165 := position, tokenIndex
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l165
 							}
 							position++
-							if buffer[position] != rune('c') {
-								goto l63
+							goto l164
+						l165:
+							position, tokenIndex = position165, tokenIndex165
+						}
+						add(ruleAction, position163)
+					}
+					add(rulePegText, position162)
+				}
+				{
+					add(ruleAction13, position)
+				}
+				if !_rules[ruleMustWhiteSpacing]() {
+					goto l160
+				}
+				{
+					position167 := position
+					{
+						position168 := position
+						if !_rules[ruleIdentifier]() {
+							goto l160
+						}
+						add(ruleEntity, position168)
+					}
+					add(rulePegText, position167)
+				}
+				{
+					add(ruleAction14, position)
+				}
+				{
+					position170, tokenIndex170 := position, tokenIndex
+					if !_rules[ruleMustWhiteSpacing]() {
+						goto l170
+					}
+					{
+						position174, tokenIndex174 := position, tokenIndex
+						{
+							position176 := position
+							if buffer[position] != rune('-') {
+								goto l175
 							}
 							position++
-							if buffer[position] != rune('e') {
-								goto l63
+							if buffer[position] != rune('-') {
+								goto l175
 							}
 							position++
-							goto l60
-						l63:
-							position, tokenIndex = position60, tokenIndex60
-							if buffer[position] != rune('r') {
-								goto l64
+							{
+								position177 := position
+								{
+									position178, tokenIndex178 := position, tokenIndex
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l179
+									}
+									position++
+									goto l178
+								l179:
+									position, tokenIndex = position178, tokenIndex178
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l175
+									}
+									position++
+								}
+							l178:
+							l180:
+								{
+									position181, tokenIndex181 := position, tokenIndex
+									{
+										switch buffer[position] {
+										case '_':
+											if buffer[position] != rune('_') {
+												goto l181
+											}
+											position++
+											break
+										case '-':
+											if buffer[position] != rune('-') {
+												goto l181
+											}
+											position++
+											break
+										case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l181
+											}
+											position++
+											break
+										case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+											if c := buffer[position]; c < rune('A') || c > rune('Z') {
+												goto l181
+											}
+											position++
+											break
+										default:
+											if c := buffer[position]; c < rune('a') || c > rune('z') {
+												goto l181
+											}
+											position++
+											break
+										}
+									}
+
+									goto l180
+								l181:
+									position, tokenIndex = position181, tokenIndex181
+								}
+								add(rulePegText, position177)
 							}
-							position++
-							if buffer[position] != rune('o') {
-								goto l64
+							{
+								position183, tokenIndex183 := position, tokenIndex
+								{
+									position184, tokenIndex184 := position, tokenIndex
+									if buffer[position] != rune('.') {
+										goto l185
+									}
+									position++
+									goto l184
+								l185:
+									position, tokenIndex = position184, tokenIndex184
+									if buffer[position] != rune('=') {
+										goto l183
+									}
+									position++
+								}
+							l184:
+								goto l175
+							l183:
+								position, tokenIndex = position183, tokenIndex183
 							}
-							position++
-							if buffer[position] != rune('l') {
-								goto l64
+							{
+								add(ruleAction19, position)
 							}
-							position++
-							if buffer[position] != rune('e') {
-								goto l64
+							add(ruleFlag, position176)
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l175
+						}
+						goto l174
+					l175:
+						position, tokenIndex = position174, tokenIndex174
+						{
+							position188 := position
+							{
+								position189 := position
+								if buffer[position] != rune('c') {
+									goto l187
+								}
+								position++
+								if buffer[position] != rune('a') {
+									goto l187
+								}
+								position++
+								if buffer[position] != rune('s') {
+									goto l187
+								}
+								position++
+								if buffer[position] != rune('c') {
+									goto l187
+								}
+								position++
+								if buffer[position] != rune('a') {
+									goto l187
+								}
+								position++
+								if buffer[position] != rune('d') {
+									goto l187
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l187
+								}
+								position++
+								add(rulePegText, position189)
 							}
-							position++
-							goto l60
-						l64:
-							position, tokenIndex = position60, tokenIndex60
-							if buffer[position] != rune('s') {
-								goto l65
+							{
+								position190, tokenIndex190 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '.':
+										if buffer[position] != rune('.') {
+											goto l190
+										}
+										position++
+										break
+									case '_':
+										if buffer[position] != rune('_') {
+											goto l190
+										}
+										position++
+										break
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l190
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l190
+										}
+										position++
+										break
+									case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l190
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l190
+										}
+										position++
+										break
+									}
+								}
+
+								goto l187
+							l190:
+								position, tokenIndex = position190, tokenIndex190
 							}
-							position++
-							if buffer[position] != rune('e') {
-								goto l65
+							{
+								position192, tokenIndex192 := position, tokenIndex
+								if !_rules[ruleSpacing]() {
+									goto l192
+								}
+								if buffer[position] != rune('=') {
+									goto l192
+								}
+								position++
+								goto l187
+							l192:
+								position, tokenIndex = position192, tokenIndex192
 							}
-							position++
-							if buffer[position] != rune('c') {
-								goto l65
+							{
+								add(ruleAction18, position)
 							}
-							position++
-							if buffer[position] != rune('u') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('r') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('i') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('t') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('y') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('g') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('r') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('o') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('u') {
-								goto l65
-							}
-							position++
-							if buffer[position] != rune('p') {
-								goto l65
-							}
-							position++
-							goto l60
-						l65:
-							position, tokenIndex = position60, tokenIndex60
-							if buffer[position] != rune('r') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('o') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('u') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('t') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('e') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('t') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('a') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('b') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('l') {
-								goto l66
-							}
-							position++
-							if buffer[position] != rune('e') {
-								goto l66
-							}
-							position++
-							goto l60
-						l66:
-							position, tokenIndex = position60, tokenIndex60
+							add(ruleModifier, position188)
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l187
+						}
+						goto l174
+					l187:
+						position, tokenIndex = position174, tokenIndex174
+						if !_rules[ruleParam]() {
+							goto l170
+						}
+					}
+				l174:
+				l172:
+					{
+						position173, tokenIndex173 := position, tokenIndex
+						{
+							position194, tokenIndex194 := position, tokenIndex
 							{
-								switch buffer[position] {
-								case 's':
-									if buffer[position] != rune('s') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('t') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('o') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('r') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('a') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('g') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('o') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('b') {
-										goto l48
+								position196 := position
+								if buffer[position] != rune('-') {
+									goto l195
+								}
+								position++
+								if buffer[position] != rune('-') {
+									goto l195
+								}
+								position++
+								{
+									position197 := position
+									{
+										position198, tokenIndex198 := position, tokenIndex
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l199
+										}
+										position++
+										goto l198
+									l199:
+										position, tokenIndex = position198, tokenIndex198
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l195
+										}
+										position++
 									}
-									position++
-									if buffer[position] != rune('j') {
-										goto l48
+								l198:
+								l200:
+									{
+										position201, tokenIndex201 := position, tokenIndex
+										{
+											switch buffer[position] {
+											case '_':
+												if buffer[position] != rune('_') {
+													goto l201
+												}
+												position++
+												break
+											case '-':
+												if buffer[position] != rune('-') {
+													goto l201
+												}
+												position++
+												break
+											case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+												if c := buffer[position]; c < rune('0') || c > rune('9') {
+													goto l201
+												}
+												position++
+												break
+											case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+												if c := buffer[position]; c < rune('A') || c > rune('Z') {
+													goto l201
+												}
+												position++
+												break
+											default:
+												if c := buffer[position]; c < rune('a') || c > rune('z') {
+													goto l201
+												}
+												position++
+												break
+											}
+										}
+
+										goto l200
+									l201:
+										position, tokenIndex = position201, tokenIndex201
 									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
+									add(rulePegText, position197)
+								}
+								{
+									position203, tokenIndex203 := position, tokenIndex
+									{
+										position204, tokenIndex204 := position, tokenIndex
+										if buffer[position] != rune('.') {
+											goto l205
+										}
+										position++
+										goto l204
+									l205:
+										position, tokenIndex = position204, tokenIndex204
+										if buffer[position] != rune('=') {
+											goto l203
+										}
+										position++
 									}
-									position++
+								l204:
+									goto l195
+								l203:
+									position, tokenIndex = position203, tokenIndex203
+								}
+								{
+									add(ruleAction19, position)
+								}
+								add(ruleFlag, position196)
+							}
+							if !_rules[ruleWhiteSpacing]() {
+								goto l195
+							}
+							goto l194
+						l195:
+							position, tokenIndex = position194, tokenIndex194
+							{
+								position208 := position
+								{
+									position209 := position
 									if buffer[position] != rune('c') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('t') {
-										goto l48
+										goto l207
 									}
 									position++
-									break
-								case 'b':
-									if buffer[position] != rune('b') {
-										goto l48
+									if buffer[position] != rune('a') {
+										goto l207
 									}
 									position++
-									if buffer[position] != rune('u') {
-										goto l48
+									if buffer[position] != rune('s') {
+										goto l207
 									}
 									position++
 									if buffer[position] != rune('c') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('k') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('t') {
-										goto l48
-									}
-									position++
-									break
-								case 'r':
-									if buffer[position] != rune('r') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('o') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('u') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('t') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
+										goto l207
 									}
 									position++
-									break
-								case 'i':
-									if buffer[position] != rune('i') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('n') {
-										goto l48
+									if buffer[position] != rune('a') {
+										goto l207
 									}
 									position++
-									if buffer[position] != rune('t') {
-										goto l48
+									if buffer[position] != rune('d') {
+										goto l207
 									}
 									position++
 									if buffer[position] != rune('e') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('r') {
-										goto l48
+										goto l207
 									}
 									position++
-									if buffer[position] != rune('n') {
-										goto l48
+									add(rulePegText, position209)
+								}
+								{
+									position210, tokenIndex210 := position, tokenIndex
+									{
+										switch buffer[position] {
+										case '.':
+											if buffer[position] != rune('.') {
+												goto l210
+											}
+											position++
+											break
+										case '_':
+											if buffer[position] != rune('_') {
+												goto l210
+											}
+											position++
+											break
+										case '-':
+											if buffer[position] != rune('-') {
+												goto l210
+											}
+											position++
+											break
+										case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l210
+											}
+											position++
+											break
+										case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+											if c := buffer[position]; c < rune('A') || c > rune('Z') {
+												goto l210
+											}
+											position++
+											break
+										default:
+											if c := buffer[position]; c < rune('a') || c > rune('z') {
+												goto l210
+											}
+											position++
+											break
+										}
 									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
+
+									goto l207
+								l210:
+									position, tokenIndex = position210, tokenIndex210
+								}
+								{
+									position212, tokenIndex212 := position, tokenIndex
+									if !_rules[ruleSpacing]() {
+										goto l212
 									}
-									position++
-									if buffer[position] != rune('t') {
-										goto l48
+									if buffer[position] != rune('=') {
+										goto l212
 									}
 									position++
-									if buffer[position] != rune('g') {
-										goto l48
+									goto l207
+								l212:
+									position, tokenIndex = position212, tokenIndex212
+								}
+								{
+									add(ruleAction18, position)
+								}
+								add(ruleModifier, position208)
+							}
+							if !_rules[ruleWhiteSpacing]() {
+								goto l207
+							}
+							goto l194
+						l207:
+							position, tokenIndex = position194, tokenIndex194
+							if !_rules[ruleParam]() {
+								goto l173
+							}
+						}
+					l194:
+						goto l172
+					l173:
+						position, tokenIndex = position173, tokenIndex173
+					}
+					goto l171
+				l170:
+					position, tokenIndex = position170, tokenIndex170
+				}
+			l171:
+				{
+					add(ruleAction15, position)
+				}
+				add(ruleExpr, position161)
+			}
+			return true
+		l160:
+			position, tokenIndex = position160, tokenIndex160
+			return false
+		},
+		/* 14 Params <- <Param+> */
+		nil,
+		/* 15 Param <- <(&{ p.alive() } <Identifier> Action16 (RegexMatch / (Equal Value)) WhiteSpacing)> */
+		func() bool {
+			position216, tokenIndex216 := position, tokenIndex
+			{
+				position217 := position
+				if !(p.alive()) {
+					goto l216
+				}
+				{
+					position218 := position
+					if !_rules[ruleIdentifier]() {
+						goto l216
+					}
+					add(rulePegText, position218)
+				}
+				{
+					add(ruleAction16, position)
+				}
+				{
+					position220, tokenIndex220 := position, tokenIndex
+					{
+						position222 := position
+						if !_rules[ruleSpacing]() {
+							goto l221
+						}
+						if buffer[position] != rune('=') {
+							goto l221
+						}
+						position++
+						if !_rules[ruleSpacing]() {
+							goto l221
+						}
+						if buffer[position] != rune('~') {
+							goto l221
+						}
+						position++
+						if !_rules[ruleWhiteSpacing]() {
+							goto l221
+						}
+						{
+							position223, tokenIndex223 := position, tokenIndex
+							if !_rules[ruleSingleQuotedValue]() {
+								goto l224
+							}
+							goto l223
+						l224:
+							position, tokenIndex = position223, tokenIndex223
+							{
+								position225 := position
+								{
+									position228, tokenIndex228 := position, tokenIndex
+									if !_rules[ruleSpace]() {
+										goto l228
 									}
-									position++
-									if buffer[position] != rune('a') {
-										goto l48
+									goto l221
+								l228:
+									position, tokenIndex = position228, tokenIndex228
+								}
+								{
+									position229, tokenIndex229 := position, tokenIndex
+									if buffer[position] != rune(';') {
+										goto l229
 									}
 									position++
-									if buffer[position] != rune('t') {
-										goto l48
+									goto l221
+								l229:
+									position, tokenIndex = position229, tokenIndex229
+								}
+								if !matchDot() {
+									goto l221
+								}
+							l226:
+								{
+									position227, tokenIndex227 := position, tokenIndex
+									{
+										position230, tokenIndex230 := position, tokenIndex
+										if !_rules[ruleSpace]() {
+											goto l230
+										}
+										goto l227
+									l230:
+										position, tokenIndex = position230, tokenIndex230
 									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
+									{
+										position231, tokenIndex231 := position, tokenIndex
+										if buffer[position] != rune(';') {
+											goto l231
+										}
+										position++
+										goto l227
+									l231:
+										position, tokenIndex = position231, tokenIndex231
 									}
-									position++
-									if buffer[position] != rune('w') {
-										goto l48
+									if !matchDot() {
+										goto l227
 									}
-									position++
-									if buffer[position] != rune('a') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('y') {
-										goto l48
-									}
-									position++
-									break
-								case 'k':
-									if buffer[position] != rune('k') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('y') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('p') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('a') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('i') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('r') {
-										goto l48
-									}
-									position++
-									break
-								case 'p':
-									if buffer[position] != rune('p') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('o') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('l') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('i') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('c') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('y') {
-										goto l48
-									}
-									position++
-									break
-								case 'g':
-									if buffer[position] != rune('g') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('r') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('o') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('u') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('p') {
-										goto l48
-									}
-									position++
-									break
-								case 'u':
-									if buffer[position] != rune('u') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('s') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('r') {
-										goto l48
-									}
-									position++
-									break
-								case 't':
-									if buffer[position] != rune('t') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('a') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('g') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('s') {
-										goto l48
-									}
-									position++
-									break
-								default:
-									if buffer[position] != rune('v') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('o') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('l') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('u') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('m') {
-										goto l48
-									}
-									position++
-									if buffer[position] != rune('e') {
-										goto l48
-									}
-									position++
-									break
+									goto l226
+								l227:
+									position, tokenIndex = position227, tokenIndex227
 								}
+								add(rulePegText, position225)
 							}
-
 						}
-					l60:
-						add(ruleEntity, position59)
+					l223:
+						{
+							add(ruleAction17, position)
+						}
+						add(ruleRegexMatch, position222)
+					}
+					goto l220
+				l221:
+					position, tokenIndex = position220, tokenIndex220
+					if !_rules[ruleEqual]() {
+						goto l216
+					}
+					if !_rules[ruleValue]() {
+						goto l216
+					}
+				}
+			l220:
+				if !_rules[ruleWhiteSpacing]() {
+					goto l216
+				}
+				add(ruleParam, position217)
+			}
+			return true
+		l216:
+			position, tokenIndex = position216, tokenIndex216
+			return false
+		},
+		/* 16 RegexMatch <- <(Spacing '=' Spacing '~' WhiteSpacing (SingleQuotedValue / <(!Space !';' .)+>) Action17)> */
+		nil,
+		/* 17 Modifier <- <(<('c' 'a' 's' 'c' 'a' 'd' 'e')> !((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])) !(Spacing '=') Action18)> */
+		nil,
+		/* 18 Flag <- <('-' '-' <(([a-z] / [A-Z]) ((&('_') '_') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> !('.' / '=') Action19)> */
+		nil,
+		/* 19 Identifier <- <((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		func() bool {
+			position236, tokenIndex236 := position, tokenIndex
+			{
+				position237 := position
+				{
+					switch buffer[position] {
+					case '.':
+						if buffer[position] != rune('.') {
+							goto l236
+						}
+						position++
+						break
+					case '_':
+						if buffer[position] != rune('_') {
+							goto l236
+						}
+						position++
+						break
+					case '-':
+						if buffer[position] != rune('-') {
+							goto l236
+						}
+						position++
+						break
+					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l236
+						}
+						position++
+						break
+					default:
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l236
+						}
+						position++
+						break
 					}
-					add(rulePegText, position58)
 				}
+
+			l238:
 				{
-					add(ruleAction2, position)
+					position239, tokenIndex239 := position, tokenIndex
+					{
+						switch buffer[position] {
+						case '.':
+							if buffer[position] != rune('.') {
+								goto l239
+							}
+							position++
+							break
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l239
+							}
+							position++
+							break
+						case '-':
+							if buffer[position] != rune('-') {
+								goto l239
+							}
+							position++
+							break
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l239
+							}
+							position++
+							break
+						default:
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l239
+							}
+							position++
+							break
+						}
+					}
+
+					goto l238
+				l239:
+					position, tokenIndex = position239, tokenIndex239
 				}
+				add(ruleIdentifier, position237)
+			}
+			return true
+		l236:
+			position, tokenIndex = position236, tokenIndex236
+			return false
+		},
+		/* 20 Name <- <(!([0-9]+ !((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))) ((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+)> */
+		func() bool {
+			position242, tokenIndex242 := position, tokenIndex
+			{
+				position243 := position
 				{
-					position69, tokenIndex69 := position, tokenIndex
-					if !_rules[ruleMustWhiteSpacing]() {
-						goto l69
+					position244, tokenIndex244 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l244
+					}
+					position++
+				l245:
+					{
+						position246, tokenIndex246 := position, tokenIndex
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l246
+						}
+						position++
+						goto l245
+					l246:
+						position, tokenIndex = position246, tokenIndex246
 					}
 					{
-						position71 := position
+						position247, tokenIndex247 := position, tokenIndex
 						{
-							position74 := position
-							{
-								position75 := position
-								if !_rules[ruleIdentifier]() {
-									goto l69
+							switch buffer[position] {
+							case '.':
+								if buffer[position] != rune('.') {
+									goto l247
+								}
+								position++
+								break
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l247
+								}
+								position++
+								break
+							case '-':
+								if buffer[position] != rune('-') {
+									goto l247
+								}
+								position++
+								break
+							case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l247
+								}
+								position++
+								break
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l247
+								}
+								position++
+								break
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l247
 								}
-								add(rulePegText, position75)
+								position++
+								break
 							}
-							{
-								add(ruleAction4, position)
+						}
+
+						goto l244
+					l247:
+						position, tokenIndex = position247, tokenIndex247
+					}
+					goto l242
+				l244:
+					position, tokenIndex = position244, tokenIndex244
+				}
+				{
+					switch buffer[position] {
+					case '.':
+						if buffer[position] != rune('.') {
+							goto l242
+						}
+						position++
+						break
+					case '_':
+						if buffer[position] != rune('_') {
+							goto l242
+						}
+						position++
+						break
+					case '-':
+						if buffer[position] != rune('-') {
+							goto l242
+						}
+						position++
+						break
+					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l242
+						}
+						position++
+						break
+					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l242
+						}
+						position++
+						break
+					default:
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l242
+						}
+						position++
+						break
+					}
+				}
+
+			l249:
+				{
+					position250, tokenIndex250 := position, tokenIndex
+					{
+						switch buffer[position] {
+						case '.':
+							if buffer[position] != rune('.') {
+								goto l250
 							}
-							if !_rules[ruleEqual]() {
-								goto l69
+							position++
+							break
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l250
 							}
-							{
-								position77 := position
-								{
-									position78, tokenIndex78 := position, tokenIndex
-									{
-										position80 := position
-										{
-											position81 := position
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l79
-											}
-											position++
-										l82:
-											{
-												position83, tokenIndex83 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l83
-												}
-												position++
-												goto l82
-											l83:
-												position, tokenIndex = position83, tokenIndex83
-											}
-											if !matchDot() {
-												goto l79
-											}
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l79
-											}
-											position++
-										l84:
-											{
-												position85, tokenIndex85 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l85
-												}
-												position++
-												goto l84
-											l85:
-												position, tokenIndex = position85, tokenIndex85
-											}
-											if !matchDot() {
-												goto l79
-											}
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l79
-											}
-											position++
-										l86:
-											{
-												position87, tokenIndex87 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l87
-												}
-												position++
-												goto l86
-											l87:
-												position, tokenIndex = position87, tokenIndex87
-											}
-											if !matchDot() {
-												goto l79
-											}
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l79
-											}
-											position++
-										l88:
-											{
-												position89, tokenIndex89 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l89
-												}
-												position++
-												goto l88
-											l89:
-												position, tokenIndex = position89, tokenIndex89
-											}
-											if buffer[position] != rune('/') {
-												goto l79
-											}
-											position++
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l79
-											}
-											position++
-										l90:
-											{
-												position91, tokenIndex91 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l91
-												}
-												position++
-												goto l90
-											l91:
-												position, tokenIndex = position91, tokenIndex91
-											}
-											add(ruleCidrValue, position81)
+							position++
+							break
+						case '-':
+							if buffer[position] != rune('-') {
+								goto l250
+							}
+							position++
+							break
+						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l250
+							}
+							position++
+							break
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l250
+							}
+							position++
+							break
+						default:
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l250
+							}
+							position++
+							break
+						}
+					}
+
+					goto l249
+				l250:
+					position, tokenIndex = position250, tokenIndex250
+				}
+				add(ruleName, position243)
+			}
+			return true
+		l242:
+			position, tokenIndex = position242, tokenIndex242
+			return false
+		},
+		/* 21 QuotedName <- <('"' (('\\' .) / (!'"' .))* '"')> */
+		func() bool {
+			position253, tokenIndex253 := position, tokenIndex
+			{
+				position254 := position
+				if buffer[position] != rune('"') {
+					goto l253
+				}
+				position++
+			l255:
+				{
+					position256, tokenIndex256 := position, tokenIndex
+					{
+						position257, tokenIndex257 := position, tokenIndex
+						if buffer[position] != rune('\\') {
+							goto l258
+						}
+						position++
+						if !matchDot() {
+							goto l258
+						}
+						goto l257
+					l258:
+						position, tokenIndex = position257, tokenIndex257
+						{
+							position259, tokenIndex259 := position, tokenIndex
+							if buffer[position] != rune('"') {
+								goto l259
+							}
+							position++
+							goto l256
+						l259:
+							position, tokenIndex = position259, tokenIndex259
+						}
+						if !matchDot() {
+							goto l256
+						}
+					}
+				l257:
+					goto l255
+				l256:
+					position, tokenIndex = position256, tokenIndex256
+				}
+				if buffer[position] != rune('"') {
+					goto l253
+				}
+				position++
+				add(ruleQuotedName, position254)
+			}
+			return true
+		l253:
+			position, tokenIndex = position253, tokenIndex253
+			return false
+		},
+		/* 22 Value <- <((EnvValue Action21) / (<ListValue> Action22) / (<PercentOfValue> Action23) / ((&('<') (HeredocValue Action20)) | (&('[') BracketListValue) | (&('"' | '#' | '$' | '\'' | '-' | '.' | '/' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | ':' | '@' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z' | '{') ItemValue)))> */
+		func() bool {
+			position260, tokenIndex260 := position, tokenIndex
+			{
+				position261 := position
+				{
+					position262, tokenIndex262 := position, tokenIndex
+					{
+						position264 := position
+						if buffer[position] != rune('$') {
+							goto l263
+						}
+						position++
+						if buffer[position] != rune('{') {
+							goto l263
+						}
+						position++
+						if buffer[position] != rune('E') {
+							goto l263
+						}
+						position++
+						if buffer[position] != rune('N') {
+							goto l263
+						}
+						position++
+						if buffer[position] != rune('V') {
+							goto l263
+						}
+						position++
+						if buffer[position] != rune(':') {
+							goto l263
+						}
+						position++
+						{
+							position265 := position
+							{
+								switch buffer[position] {
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l263
+									}
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l263
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l263
+									}
+									position++
+									break
+								}
+							}
+
+						l267:
+							{
+								position268, tokenIndex268 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '_':
+										if buffer[position] != rune('_') {
+											goto l268
 										}
-										add(rulePegText, position80)
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l268
+										}
+										position++
+										break
+									case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l268
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l268
+										}
+										position++
+										break
 									}
-									{
-										add(ruleAction8, position)
+								}
+
+								goto l267
+							l268:
+								position, tokenIndex = position268, tokenIndex268
+							}
+							add(rulePegText, position265)
+						}
+						if buffer[position] != rune('}') {
+							goto l263
+						}
+						position++
+						add(ruleEnvValue, position264)
+					}
+					{
+						add(ruleAction21, position)
+					}
+					goto l262
+				l263:
+					position, tokenIndex = position262, tokenIndex262
+					{
+						position272 := position
+						{
+							position273 := position
+							if !_rules[ruleStringValue]() {
+								goto l271
+							}
+							if buffer[position] != rune(',') {
+								goto l271
+							}
+							position++
+							if !_rules[ruleStringValue]() {
+								goto l271
+							}
+						l274:
+							{
+								position275, tokenIndex275 := position, tokenIndex
+								if buffer[position] != rune(',') {
+									goto l275
+								}
+								position++
+								if !_rules[ruleStringValue]() {
+									goto l275
+								}
+								goto l274
+							l275:
+								position, tokenIndex = position275, tokenIndex275
+							}
+							add(ruleListValue, position273)
+						}
+						add(rulePegText, position272)
+					}
+					{
+						add(ruleAction22, position)
+					}
+					goto l262
+				l271:
+					position, tokenIndex = position262, tokenIndex262
+					{
+						position278 := position
+						{
+							position279 := position
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l277
+							}
+							position++
+						l280:
+							{
+								position281, tokenIndex281 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l281
+								}
+								position++
+								goto l280
+							l281:
+								position, tokenIndex = position281, tokenIndex281
+							}
+							{
+								position282, tokenIndex282 := position, tokenIndex
+								if buffer[position] != rune('.') {
+									goto l282
+								}
+								position++
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l282
+								}
+								position++
+							l284:
+								{
+									position285, tokenIndex285 := position, tokenIndex
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l285
+									}
+									position++
+									goto l284
+								l285:
+									position, tokenIndex = position285, tokenIndex285
+								}
+								goto l283
+							l282:
+								position, tokenIndex = position282, tokenIndex282
+							}
+						l283:
+							if buffer[position] != rune('%') {
+								goto l277
+							}
+							position++
+							if buffer[position] != rune('o') {
+								goto l277
+							}
+							position++
+							if buffer[position] != rune('f') {
+								goto l277
+							}
+							position++
+							if buffer[position] != rune('$') {
+								goto l277
+							}
+							position++
+							{
+								position286, tokenIndex286 := position, tokenIndex
+								if !_rules[ruleQuotedName]() {
+									goto l287
+								}
+								goto l286
+							l287:
+								position, tokenIndex = position286, tokenIndex286
+								if !_rules[ruleName]() {
+									goto l277
+								}
+							}
+						l286:
+							add(rulePercentOfValue, position279)
+						}
+						add(rulePegText, position278)
+					}
+					{
+						add(ruleAction23, position)
+					}
+					goto l262
+				l277:
+					position, tokenIndex = position262, tokenIndex262
+					{
+						switch buffer[position] {
+						case '<':
+							{
+								position290 := position
+								{
+									position291 := position
+									if buffer[position] != rune('<') {
+										goto l260
+									}
+									position++
+									if buffer[position] != rune('<') {
+										goto l260
+									}
+									position++
+									if buffer[position] != rune('E') {
+										goto l260
+									}
+									position++
+									if buffer[position] != rune('O') {
+										goto l260
+									}
+									position++
+									if buffer[position] != rune('F') {
+										goto l260
+									}
+									position++
+									add(ruleHeredocStart, position291)
+								}
+								{
+									position292, tokenIndex292 := position, tokenIndex
+									if buffer[position] != rune('\r') {
+										goto l293
+									}
+									position++
+									if buffer[position] != rune('\n') {
+										goto l293
+									}
+									position++
+									goto l292
+								l293:
+									position, tokenIndex = position292, tokenIndex292
+									if buffer[position] != rune('\n') {
+										goto l260
 									}
-									goto l78
-								l79:
-									position, tokenIndex = position78, tokenIndex78
+									position++
+								}
+							l292:
+								{
+									position294, tokenIndex294 := position, tokenIndex
+								l295:
 									{
-										position94 := position
+										position296, tokenIndex296 := position, tokenIndex
 										{
-											position95 := position
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l93
-											}
-											position++
-										l96:
-											{
-												position97, tokenIndex97 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l97
-												}
-												position++
-												goto l96
-											l97:
-												position, tokenIndex = position97, tokenIndex97
-											}
-											if !matchDot() {
-												goto l93
-											}
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l93
-											}
-											position++
-										l98:
-											{
-												position99, tokenIndex99 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l99
-												}
-												position++
-												goto l98
-											l99:
-												position, tokenIndex = position99, tokenIndex99
-											}
-											if !matchDot() {
-												goto l93
-											}
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l93
+											position297, tokenIndex297 := position, tokenIndex
+											if !_rules[ruleHeredocEnd]() {
+												goto l297
 											}
-											position++
-										l100:
-											{
-												position101, tokenIndex101 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l101
-												}
-												position++
-												goto l100
-											l101:
-												position, tokenIndex = position101, tokenIndex101
-											}
-											if !matchDot() {
-												goto l93
-											}
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l93
-											}
-											position++
-										l102:
-											{
-												position103, tokenIndex103 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l103
-												}
-												position++
-												goto l102
-											l103:
-												position, tokenIndex = position103, tokenIndex103
-											}
-											add(ruleIpValue, position95)
+											goto l296
+										l297:
+											position, tokenIndex = position297, tokenIndex297
 										}
-										add(rulePegText, position94)
+										if !matchDot() {
+											goto l296
+										}
+										goto l295
+									l296:
+										position, tokenIndex = position296, tokenIndex296
 									}
-									{
-										add(ruleAction9, position)
+									if !_rules[ruleHeredocEnd]() {
+										goto l260
 									}
-									goto l78
-								l93:
-									position, tokenIndex = position78, tokenIndex78
+									position, tokenIndex = position294, tokenIndex294
+								}
+								{
+									position298 := position
+								l299:
 									{
-										position106 := position
+										position300, tokenIndex300 := position, tokenIndex
 										{
-											position107 := position
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l105
-											}
-											position++
-										l108:
-											{
-												position109, tokenIndex109 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l109
-												}
-												position++
-												goto l108
-											l109:
-												position, tokenIndex = position109, tokenIndex109
+											position301, tokenIndex301 := position, tokenIndex
+											if !_rules[ruleHeredocEnd]() {
+												goto l301
 											}
-											if buffer[position] != rune('-') {
-												goto l105
-											}
-											position++
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l105
-											}
-											position++
-										l110:
-											{
-												position111, tokenIndex111 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l111
-												}
-												position++
-												goto l110
-											l111:
-												position, tokenIndex = position111, tokenIndex111
-											}
-											add(ruleIntRangeValue, position107)
-										}
-										add(rulePegText, position106)
-									}
-									{
-										add(ruleAction10, position)
-									}
-									goto l78
-								l105:
-									position, tokenIndex = position78, tokenIndex78
-									{
-										position114 := position
-										{
-											position115 := position
-											if c := buffer[position]; c < rune('0') || c > rune('9') {
-												goto l113
-											}
-											position++
-										l116:
-											{
-												position117, tokenIndex117 := position, tokenIndex
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l117
-												}
-												position++
-												goto l116
-											l117:
-												position, tokenIndex = position117, tokenIndex117
-											}
-											add(ruleIntValue, position115)
+											goto l300
+										l301:
+											position, tokenIndex = position301, tokenIndex301
 										}
-										add(rulePegText, position114)
-									}
-									{
-										add(ruleAction11, position)
-									}
-									goto l78
-								l113:
-									position, tokenIndex = position78, tokenIndex78
-									{
-										switch buffer[position] {
-										case '$':
-											{
-												position120 := position
-												if buffer[position] != rune('$') {
-													goto l69
-												}
-												position++
-												{
-													position121 := position
-													if !_rules[ruleIdentifier]() {
-														goto l69
-													}
-													add(rulePegText, position121)
-												}
-												add(ruleRefValue, position120)
-											}
-											{
-												add(ruleAction7, position)
-											}
-											break
-										case '@':
-											{
-												position123 := position
-												if buffer[position] != rune('@') {
-													goto l69
-												}
-												position++
-												{
-													position124 := position
-													if !_rules[ruleIdentifier]() {
-														goto l69
-													}
-													add(rulePegText, position124)
-												}
-												add(ruleAliasValue, position123)
-											}
-											{
-												add(ruleAction6, position)
-											}
-											break
-										case '{':
-											{
-												position126 := position
-												if buffer[position] != rune('{') {
-													goto l69
-												}
-												position++
-												if !_rules[ruleWhiteSpacing]() {
-													goto l69
-												}
-												{
-													position127 := position
-													if !_rules[ruleIdentifier]() {
-														goto l69
-													}
-													add(rulePegText, position127)
-												}
-												if !_rules[ruleWhiteSpacing]() {
-													goto l69
-												}
-												if buffer[position] != rune('}') {
-													goto l69
-												}
-												position++
-												add(ruleHoleValue, position126)
-											}
-											{
-												add(ruleAction5, position)
-											}
-											break
-										default:
-											{
-												position129 := position
-												{
-													position130 := position
-													{
-														switch buffer[position] {
-														case '/':
-															if buffer[position] != rune('/') {
-																goto l69
-															}
-															position++
-															break
-														case ':':
-															if buffer[position] != rune(':') {
-																goto l69
-															}
-															position++
-															break
-														case '_':
-															if buffer[position] != rune('_') {
-																goto l69
-															}
-															position++
-															break
-														case '.':
-															if buffer[position] != rune('.') {
-																goto l69
-															}
-															position++
-															break
-														case '-':
-															if buffer[position] != rune('-') {
-																goto l69
-															}
-															position++
-															break
-														case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-															if c := buffer[position]; c < rune('0') || c > rune('9') {
-																goto l69
-															}
-															position++
-															break
-														case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
-															if c := buffer[position]; c < rune('A') || c > rune('Z') {
-																goto l69
-															}
-															position++
-															break
-														default:
-															if c := buffer[position]; c < rune('a') || c > rune('z') {
-																goto l69
-															}
-															position++
-															break
-														}
-													}
-
-												l131:
-													{
-														position132, tokenIndex132 := position, tokenIndex
-														{
-															switch buffer[position] {
-															case '/':
-																if buffer[position] != rune('/') {
-																	goto l132
-																}
-																position++
-																break
-															case ':':
-																if buffer[position] != rune(':') {
-																	goto l132
-																}
-																position++
-																break
-															case '_':
-																if buffer[position] != rune('_') {
-																	goto l132
-																}
-																position++
-																break
-															case '.':
-																if buffer[position] != rune('.') {
-																	goto l132
-																}
-																position++
-																break
-															case '-':
-																if buffer[position] != rune('-') {
-																	goto l132
-																}
-																position++
-																break
-															case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-																if c := buffer[position]; c < rune('0') || c > rune('9') {
-																	goto l132
-																}
-																position++
-																break
-															case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
-																if c := buffer[position]; c < rune('A') || c > rune('Z') {
-																	goto l132
-																}
-																position++
-																break
-															default:
-																if c := buffer[position]; c < rune('a') || c > rune('z') {
-																	goto l132
-																}
-																position++
-																break
-															}
-														}
-
-														goto l131
-													l132:
-														position, tokenIndex = position132, tokenIndex132
-													}
-													add(ruleStringValue, position130)
-												}
-												add(rulePegText, position129)
-											}
-											{
-												add(ruleAction12, position)
-											}
-											break
+										if !matchDot() {
+											goto l300
 										}
+										goto l299
+									l300:
+										position, tokenIndex = position300, tokenIndex300
 									}
-
+									add(rulePegText, position298)
 								}
-							l78:
-								add(ruleValue, position77)
+								if !_rules[ruleHeredocEnd]() {
+									goto l260
+								}
+								add(ruleHeredocValue, position290)
 							}
-							if !_rules[ruleWhiteSpacing]() {
-								goto l69
+							{
+								add(ruleAction20, position)
 							}
-							add(ruleParam, position74)
-						}
-					l72:
-						{
-							position73, tokenIndex73 := position, tokenIndex
+							break
+						case '[':
 							{
-								position136 := position
-								{
-									position137 := position
-									if !_rules[ruleIdentifier]() {
-										goto l73
-									}
-									add(rulePegText, position137)
+								position303 := position
+								if buffer[position] != rune('[') {
+									goto l260
 								}
+								position++
 								{
-									add(ruleAction4, position)
+									add(ruleAction40, position)
 								}
-								if !_rules[ruleEqual]() {
-									goto l73
+								if !_rules[ruleWhiteSpacing]() {
+									goto l260
 								}
 								{
-									position139 := position
+									position305, tokenIndex305 := position, tokenIndex
+									if !_rules[ruleListItem]() {
+										goto l305
+									}
+								l307:
 									{
-										position140, tokenIndex140 := position, tokenIndex
-										{
-											position142 := position
-											{
-												position143 := position
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l141
-												}
-												position++
-											l144:
-												{
-													position145, tokenIndex145 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l145
-													}
-													position++
-													goto l144
-												l145:
-													position, tokenIndex = position145, tokenIndex145
-												}
-												if !matchDot() {
-													goto l141
-												}
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l141
-												}
-												position++
-											l146:
-												{
-													position147, tokenIndex147 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l147
-													}
-													position++
-													goto l146
-												l147:
-													position, tokenIndex = position147, tokenIndex147
-												}
-												if !matchDot() {
-													goto l141
-												}
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l141
-												}
-												position++
-											l148:
-												{
-													position149, tokenIndex149 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l149
-													}
-													position++
-													goto l148
-												l149:
-													position, tokenIndex = position149, tokenIndex149
-												}
-												if !matchDot() {
-													goto l141
-												}
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l141
-												}
-												position++
-											l150:
-												{
-													position151, tokenIndex151 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l151
-													}
-													position++
-													goto l150
-												l151:
-													position, tokenIndex = position151, tokenIndex151
-												}
-												if buffer[position] != rune('/') {
-													goto l141
-												}
-												position++
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l141
-												}
-												position++
-											l152:
-												{
-													position153, tokenIndex153 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l153
-													}
-													position++
-													goto l152
-												l153:
-													position, tokenIndex = position153, tokenIndex153
-												}
-												add(ruleCidrValue, position143)
-											}
-											add(rulePegText, position142)
+										position308, tokenIndex308 := position, tokenIndex
+										if !_rules[ruleWhiteSpacing]() {
+											goto l308
 										}
-										{
-											add(ruleAction8, position)
+										if buffer[position] != rune(',') {
+											goto l308
 										}
-										goto l140
-									l141:
-										position, tokenIndex = position140, tokenIndex140
-										{
-											position156 := position
-											{
-												position157 := position
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l155
-												}
-												position++
-											l158:
-												{
-													position159, tokenIndex159 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l159
-													}
-													position++
-													goto l158
-												l159:
-													position, tokenIndex = position159, tokenIndex159
-												}
-												if !matchDot() {
-													goto l155
-												}
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l155
-												}
-												position++
-											l160:
-												{
-													position161, tokenIndex161 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l161
-													}
-													position++
-													goto l160
-												l161:
-													position, tokenIndex = position161, tokenIndex161
-												}
-												if !matchDot() {
-													goto l155
-												}
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l155
-												}
-												position++
-											l162:
-												{
-													position163, tokenIndex163 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l163
-													}
-													position++
-													goto l162
-												l163:
-													position, tokenIndex = position163, tokenIndex163
-												}
-												if !matchDot() {
-													goto l155
-												}
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l155
-												}
-												position++
-											l164:
-												{
-													position165, tokenIndex165 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l165
-													}
-													position++
-													goto l164
-												l165:
-													position, tokenIndex = position165, tokenIndex165
-												}
-												add(ruleIpValue, position157)
-											}
-											add(rulePegText, position156)
+										position++
+										if !_rules[ruleWhiteSpacing]() {
+											goto l308
 										}
-										{
-											add(ruleAction9, position)
+										if !_rules[ruleListItem]() {
+											goto l308
 										}
-										goto l140
-									l155:
-										position, tokenIndex = position140, tokenIndex140
-										{
-											position168 := position
-											{
-												position169 := position
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l167
-												}
-												position++
-											l170:
-												{
-													position171, tokenIndex171 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l171
-													}
-													position++
-													goto l170
-												l171:
-													position, tokenIndex = position171, tokenIndex171
-												}
-												if buffer[position] != rune('-') {
-													goto l167
-												}
-												position++
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l167
-												}
-												position++
-											l172:
-												{
-													position173, tokenIndex173 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l173
-													}
-													position++
-													goto l172
-												l173:
-													position, tokenIndex = position173, tokenIndex173
-												}
-												add(ruleIntRangeValue, position169)
-											}
-											add(rulePegText, position168)
+										goto l307
+									l308:
+										position, tokenIndex = position308, tokenIndex308
+									}
+									{
+										position309, tokenIndex309 := position, tokenIndex
+										if !_rules[ruleWhiteSpacing]() {
+											goto l309
 										}
-										{
-											add(ruleAction10, position)
+										if buffer[position] != rune(',') {
+											goto l309
 										}
-										goto l140
-									l167:
-										position, tokenIndex = position140, tokenIndex140
-										{
-											position176 := position
-											{
-												position177 := position
-												if c := buffer[position]; c < rune('0') || c > rune('9') {
-													goto l175
-												}
-												position++
-											l178:
-												{
-													position179, tokenIndex179 := position, tokenIndex
-													if c := buffer[position]; c < rune('0') || c > rune('9') {
-														goto l179
-													}
-													position++
-													goto l178
-												l179:
-													position, tokenIndex = position179, tokenIndex179
-												}
-												add(ruleIntValue, position177)
+										position++
+										goto l310
+									l309:
+										position, tokenIndex = position309, tokenIndex309
+									}
+								l310:
+									goto l306
+								l305:
+									position, tokenIndex = position305, tokenIndex305
+								}
+							l306:
+								if !_rules[ruleWhiteSpacing]() {
+									goto l260
+								}
+								if buffer[position] != rune(']') {
+									goto l260
+								}
+								position++
+								{
+									add(ruleAction41, position)
+								}
+								add(ruleBracketListValue, position303)
+							}
+							break
+						default:
+							if !_rules[ruleItemValue]() {
+								goto l260
+							}
+							break
+						}
+					}
+
+				}
+			l262:
+				add(ruleValue, position261)
+			}
+			return true
+		l260:
+			position, tokenIndex = position260, tokenIndex260
+			return false
+		},
+		/* 23 ItemValue <- <((<CidrValue> Action28) / (<IpValue> Action29) / (<HexValue> Action30) / (<IntRangeValue> Action31) / (<SizeValue> Action32) / (<FloatValue> Action34) / (<IntValue> Action35) / (<ArnValue> Action36) / (<ResourceIdValue> Action37) / (NullValue Action38) / ((&('#') (<ColorValue> Action33)) | (&('$') (RefValue Action27)) | (&('@') (AliasValue Action26)) | (&('"') (DoubleQuotedValue Action25)) | (&('\'') (SingleQuotedValue Action24)) | (&('{') HoleValue) | (&('-' | '.' | '/' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | ':' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') (<StringValue> Action39))))> */
+		func() bool {
+			position312, tokenIndex312 := position, tokenIndex
+			{
+				position313 := position
+				{
+					position314, tokenIndex314 := position, tokenIndex
+					{
+						position316 := position
+						{
+							position317 := position
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l315
+							}
+							position++
+						l318:
+							{
+								position319, tokenIndex319 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l319
+								}
+								position++
+								goto l318
+							l319:
+								position, tokenIndex = position319, tokenIndex319
+							}
+							if buffer[position] != rune('.') {
+								goto l315
+							}
+							position++
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l315
+							}
+							position++
+						l320:
+							{
+								position321, tokenIndex321 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l321
+								}
+								position++
+								goto l320
+							l321:
+								position, tokenIndex = position321, tokenIndex321
+							}
+							if buffer[position] != rune('.') {
+								goto l315
+							}
+							position++
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l315
+							}
+							position++
+						l322:
+							{
+								position323, tokenIndex323 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l323
+								}
+								position++
+								goto l322
+							l323:
+								position, tokenIndex = position323, tokenIndex323
+							}
+							if buffer[position] != rune('.') {
+								goto l315
+							}
+							position++
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l315
+							}
+							position++
+						l324:
+							{
+								position325, tokenIndex325 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l325
+								}
+								position++
+								goto l324
+							l325:
+								position, tokenIndex = position325, tokenIndex325
+							}
+							if buffer[position] != rune('/') {
+								goto l315
+							}
+							position++
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l315
+							}
+							position++
+						l326:
+							{
+								position327, tokenIndex327 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l327
+								}
+								position++
+								goto l326
+							l327:
+								position, tokenIndex = position327, tokenIndex327
+							}
+							add(ruleCidrValue, position317)
+						}
+						add(rulePegText, position316)
+					}
+					{
+						add(ruleAction28, position)
+					}
+					goto l314
+				l315:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position330 := position
+						{
+							position331 := position
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l329
+							}
+							position++
+						l332:
+							{
+								position333, tokenIndex333 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l333
+								}
+								position++
+								goto l332
+							l333:
+								position, tokenIndex = position333, tokenIndex333
+							}
+							if buffer[position] != rune('.') {
+								goto l329
+							}
+							position++
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l329
+							}
+							position++
+						l334:
+							{
+								position335, tokenIndex335 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l335
+								}
+								position++
+								goto l334
+							l335:
+								position, tokenIndex = position335, tokenIndex335
+							}
+							if buffer[position] != rune('.') {
+								goto l329
+							}
+							position++
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l329
+							}
+							position++
+						l336:
+							{
+								position337, tokenIndex337 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l337
+								}
+								position++
+								goto l336
+							l337:
+								position, tokenIndex = position337, tokenIndex337
+							}
+							if buffer[position] != rune('.') {
+								goto l329
+							}
+							position++
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l329
+							}
+							position++
+						l338:
+							{
+								position339, tokenIndex339 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l339
+								}
+								position++
+								goto l338
+							l339:
+								position, tokenIndex = position339, tokenIndex339
+							}
+							add(ruleIpValue, position331)
+						}
+						add(rulePegText, position330)
+					}
+					{
+						add(ruleAction29, position)
+					}
+					goto l314
+				l329:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position342 := position
+						{
+							position343 := position
+							if buffer[position] != rune('0') {
+								goto l341
+							}
+							position++
+							{
+								position344, tokenIndex344 := position, tokenIndex
+								if buffer[position] != rune('x') {
+									goto l345
+								}
+								position++
+								goto l344
+							l345:
+								position, tokenIndex = position344, tokenIndex344
+								if buffer[position] != rune('X') {
+									goto l341
+								}
+								position++
+							}
+						l344:
+							{
+								switch buffer[position] {
+								case 'A', 'B', 'C', 'D', 'E', 'F':
+									if c := buffer[position]; c < rune('A') || c > rune('F') {
+										goto l341
+									}
+									position++
+									break
+								case 'a', 'b', 'c', 'd', 'e', 'f':
+									if c := buffer[position]; c < rune('a') || c > rune('f') {
+										goto l341
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l341
+									}
+									position++
+									break
+								}
+							}
+
+						l346:
+							{
+								position347, tokenIndex347 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case 'A', 'B', 'C', 'D', 'E', 'F':
+										if c := buffer[position]; c < rune('A') || c > rune('F') {
+											goto l347
+										}
+										position++
+										break
+									case 'a', 'b', 'c', 'd', 'e', 'f':
+										if c := buffer[position]; c < rune('a') || c > rune('f') {
+											goto l347
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l347
+										}
+										position++
+										break
+									}
+								}
+
+								goto l346
+							l347:
+								position, tokenIndex = position347, tokenIndex347
+							}
+							{
+								position350, tokenIndex350 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '/':
+										if buffer[position] != rune('/') {
+											goto l350
+										}
+										position++
+										break
+									case ':':
+										if buffer[position] != rune(':') {
+											goto l350
+										}
+										position++
+										break
+									case '_':
+										if buffer[position] != rune('_') {
+											goto l350
+										}
+										position++
+										break
+									case '.':
+										if buffer[position] != rune('.') {
+											goto l350
+										}
+										position++
+										break
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l350
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l350
+										}
+										position++
+										break
+									case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l350
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l350
+										}
+										position++
+										break
+									}
+								}
+
+								goto l341
+							l350:
+								position, tokenIndex = position350, tokenIndex350
+							}
+							add(ruleHexValue, position343)
+						}
+						add(rulePegText, position342)
+					}
+					{
+						add(ruleAction30, position)
+					}
+					goto l314
+				l341:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position354 := position
+						{
+							position355 := position
+							if !_rules[ruleRangeBound]() {
+								goto l353
+							}
+							if buffer[position] != rune('-') {
+								goto l353
+							}
+							position++
+							if !_rules[ruleRangeBound]() {
+								goto l353
+							}
+							{
+								position356, tokenIndex356 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '/':
+										if buffer[position] != rune('/') {
+											goto l356
+										}
+										position++
+										break
+									case ':':
+										if buffer[position] != rune(':') {
+											goto l356
+										}
+										position++
+										break
+									case '_':
+										if buffer[position] != rune('_') {
+											goto l356
+										}
+										position++
+										break
+									case '.':
+										if buffer[position] != rune('.') {
+											goto l356
+										}
+										position++
+										break
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l356
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l356
+										}
+										position++
+										break
+									case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l356
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l356
+										}
+										position++
+										break
+									}
+								}
+
+								goto l353
+							l356:
+								position, tokenIndex = position356, tokenIndex356
+							}
+							add(ruleIntRangeValue, position355)
+						}
+						add(rulePegText, position354)
+					}
+					{
+						add(ruleAction31, position)
+					}
+					goto l314
+				l353:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position360 := position
+						{
+							position361 := position
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l359
+							}
+							position++
+						l362:
+							{
+								position363, tokenIndex363 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l363
+								}
+								position++
+								goto l362
+							l363:
+								position, tokenIndex = position363, tokenIndex363
+							}
+							{
+								switch buffer[position] {
+								case 'T', 't':
+									{
+										position365, tokenIndex365 := position, tokenIndex
+										if buffer[position] != rune('t') {
+											goto l366
+										}
+										position++
+										goto l365
+									l366:
+										position, tokenIndex = position365, tokenIndex365
+										if buffer[position] != rune('T') {
+											goto l359
+										}
+										position++
+									}
+								l365:
+									break
+								case 'G', 'g':
+									{
+										position367, tokenIndex367 := position, tokenIndex
+										if buffer[position] != rune('g') {
+											goto l368
+										}
+										position++
+										goto l367
+									l368:
+										position, tokenIndex = position367, tokenIndex367
+										if buffer[position] != rune('G') {
+											goto l359
+										}
+										position++
+									}
+								l367:
+									break
+								case 'K':
+									if buffer[position] != rune('K') {
+										goto l359
+									}
+									position++
+									break
+								case 'k':
+									if buffer[position] != rune('k') {
+										goto l359
+									}
+									position++
+									break
+								default:
+									{
+										position369, tokenIndex369 := position, tokenIndex
+										if buffer[position] != rune('m') {
+											goto l370
+										}
+										position++
+										goto l369
+									l370:
+										position, tokenIndex = position369, tokenIndex369
+										if buffer[position] != rune('M') {
+											goto l359
+										}
+										position++
+									}
+								l369:
+									break
+								}
+							}
+
+							{
+								position371, tokenIndex371 := position, tokenIndex
+								if buffer[position] != rune('b') {
+									goto l372
+								}
+								position++
+								goto l371
+							l372:
+								position, tokenIndex = position371, tokenIndex371
+								if buffer[position] != rune('B') {
+									goto l359
+								}
+								position++
+							}
+						l371:
+							{
+								position373, tokenIndex373 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '/':
+										if buffer[position] != rune('/') {
+											goto l373
+										}
+										position++
+										break
+									case ':':
+										if buffer[position] != rune(':') {
+											goto l373
+										}
+										position++
+										break
+									case '_':
+										if buffer[position] != rune('_') {
+											goto l373
+										}
+										position++
+										break
+									case '.':
+										if buffer[position] != rune('.') {
+											goto l373
+										}
+										position++
+										break
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l373
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l373
+										}
+										position++
+										break
+									case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l373
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l373
+										}
+										position++
+										break
+									}
+								}
+
+								goto l359
+							l373:
+								position, tokenIndex = position373, tokenIndex373
+							}
+							add(ruleSizeValue, position361)
+						}
+						add(rulePegText, position360)
+					}
+					{
+						add(ruleAction32, position)
+					}
+					goto l314
+				l359:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position377 := position
+						{
+							position378 := position
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l376
+							}
+							position++
+						l379:
+							{
+								position380, tokenIndex380 := position, tokenIndex
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l380
+								}
+								position++
+								goto l379
+							l380:
+								position, tokenIndex = position380, tokenIndex380
+							}
+							{
+								position381, tokenIndex381 := position, tokenIndex
+								if buffer[position] != rune('.') {
+									goto l382
+								}
+								position++
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l382
+								}
+								position++
+							l383:
+								{
+									position384, tokenIndex384 := position, tokenIndex
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l384
+									}
+									position++
+									goto l383
+								l384:
+									position, tokenIndex = position384, tokenIndex384
+								}
+								{
+									position385, tokenIndex385 := position, tokenIndex
+									if !_rules[ruleExponent]() {
+										goto l385
+									}
+									goto l386
+								l385:
+									position, tokenIndex = position385, tokenIndex385
+								}
+							l386:
+								goto l381
+							l382:
+								position, tokenIndex = position381, tokenIndex381
+								if !_rules[ruleExponent]() {
+									goto l376
+								}
+							}
+						l381:
+							{
+								position387, tokenIndex387 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '/':
+										if buffer[position] != rune('/') {
+											goto l387
+										}
+										position++
+										break
+									case ':':
+										if buffer[position] != rune(':') {
+											goto l387
+										}
+										position++
+										break
+									case '_':
+										if buffer[position] != rune('_') {
+											goto l387
+										}
+										position++
+										break
+									case '.':
+										if buffer[position] != rune('.') {
+											goto l387
+										}
+										position++
+										break
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l387
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l387
+										}
+										position++
+										break
+									case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l387
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l387
+										}
+										position++
+										break
+									}
+								}
+
+								goto l376
+							l387:
+								position, tokenIndex = position387, tokenIndex387
+							}
+							add(ruleFloatValue, position378)
+						}
+						add(rulePegText, position377)
+					}
+					{
+						add(ruleAction34, position)
+					}
+					goto l314
+				l376:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position391 := position
+						{
+							position392 := position
+							{
+								position393, tokenIndex393 := position, tokenIndex
+								if buffer[position] != rune('0') {
+									goto l394
+								}
+								position++
+								{
+									position395, tokenIndex395 := position, tokenIndex
+									if buffer[position] != rune('o') {
+										goto l396
+									}
+									position++
+									goto l395
+								l396:
+									position, tokenIndex = position395, tokenIndex395
+									if buffer[position] != rune('O') {
+										goto l394
+									}
+									position++
+								}
+							l395:
+								goto l393
+							l394:
+								position, tokenIndex = position393, tokenIndex393
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l390
+								}
+								position++
+							}
+						l393:
+						l397:
+							{
+								position398, tokenIndex398 := position, tokenIndex
+								{
+									position399, tokenIndex399 := position, tokenIndex
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l400
+									}
+									position++
+									goto l399
+								l400:
+									position, tokenIndex = position399, tokenIndex399
+									if buffer[position] != rune('_') {
+										goto l398
+									}
+									position++
+								}
+							l399:
+								goto l397
+							l398:
+								position, tokenIndex = position398, tokenIndex398
+							}
+							add(ruleIntValue, position392)
+						}
+						add(rulePegText, position391)
+					}
+					{
+						add(ruleAction35, position)
+					}
+					goto l314
+				l390:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position403 := position
+						{
+							position404 := position
+							if buffer[position] != rune('a') {
+								goto l402
+							}
+							position++
+							if buffer[position] != rune('r') {
+								goto l402
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l402
+							}
+							position++
+							if buffer[position] != rune(':') {
+								goto l402
+							}
+							position++
+							{
+								position407, tokenIndex407 := position, tokenIndex
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l408
+								}
+								position++
+								goto l407
+							l408:
+								position, tokenIndex = position407, tokenIndex407
+								if buffer[position] != rune('-') {
+									goto l402
+								}
+								position++
+							}
+						l407:
+						l405:
+							{
+								position406, tokenIndex406 := position, tokenIndex
+								{
+									position409, tokenIndex409 := position, tokenIndex
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l410
+									}
+									position++
+									goto l409
+								l410:
+									position, tokenIndex = position409, tokenIndex409
+									if buffer[position] != rune('-') {
+										goto l406
+									}
+									position++
+								}
+							l409:
+								goto l405
+							l406:
+								position, tokenIndex = position406, tokenIndex406
+							}
+							if buffer[position] != rune(':') {
+								goto l402
+							}
+							position++
+							{
+								switch buffer[position] {
+								case '-':
+									if buffer[position] != rune('-') {
+										goto l402
+									}
+									position++
+									break
+								case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l402
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l402
+									}
+									position++
+									break
+								}
+							}
+
+						l411:
+							{
+								position412, tokenIndex412 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l412
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l412
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l412
+										}
+										position++
+										break
+									}
+								}
+
+								goto l411
+							l412:
+								position, tokenIndex = position412, tokenIndex412
+							}
+							if buffer[position] != rune(':') {
+								goto l402
+							}
+							position++
+						l415:
+							{
+								position416, tokenIndex416 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l416
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l416
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l416
+										}
+										position++
+										break
+									}
+								}
+
+								goto l415
+							l416:
+								position, tokenIndex = position416, tokenIndex416
+							}
+							if buffer[position] != rune(':') {
+								goto l402
+							}
+							position++
+						l418:
+							{
+								position419, tokenIndex419 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l419
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l419
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l419
+										}
+										position++
+										break
+									}
+								}
+
+								goto l418
+							l419:
+								position, tokenIndex = position419, tokenIndex419
+							}
+							if buffer[position] != rune(':') {
+								goto l402
+							}
+							position++
+							{
+								switch buffer[position] {
+								case '/':
+									if buffer[position] != rune('/') {
+										goto l402
+									}
+									position++
+									break
+								case ':':
+									if buffer[position] != rune(':') {
+										goto l402
+									}
+									position++
+									break
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l402
+									}
+									position++
+									break
+								case '.':
+									if buffer[position] != rune('.') {
+										goto l402
+									}
+									position++
+									break
+								case '-':
+									if buffer[position] != rune('-') {
+										goto l402
+									}
+									position++
+									break
+								case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l402
+									}
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l402
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l402
+									}
+									position++
+									break
+								}
+							}
+
+						l421:
+							{
+								position422, tokenIndex422 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '/':
+										if buffer[position] != rune('/') {
+											goto l422
+										}
+										position++
+										break
+									case ':':
+										if buffer[position] != rune(':') {
+											goto l422
+										}
+										position++
+										break
+									case '_':
+										if buffer[position] != rune('_') {
+											goto l422
+										}
+										position++
+										break
+									case '.':
+										if buffer[position] != rune('.') {
+											goto l422
+										}
+										position++
+										break
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l422
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l422
+										}
+										position++
+										break
+									case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l422
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l422
+										}
+										position++
+										break
+									}
+								}
+
+								goto l421
+							l422:
+								position, tokenIndex = position422, tokenIndex422
+							}
+							add(ruleArnValue, position404)
+						}
+						add(rulePegText, position403)
+					}
+					{
+						add(ruleAction36, position)
+					}
+					goto l314
+				l402:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position427 := position
+						{
+							position428 := position
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l426
+							}
+							position++
+						l429:
+							{
+								position430, tokenIndex430 := position, tokenIndex
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l430
+								}
+								position++
+								goto l429
+							l430:
+								position, tokenIndex = position430, tokenIndex430
+							}
+							if buffer[position] != rune('-') {
+								goto l426
+							}
+							position++
+							{
+								position431, tokenIndex431 := position, tokenIndex
+								if !_rules[ruleHexOctet]() {
+									goto l432
+								}
+								if !_rules[ruleHexOctet]() {
+									goto l432
+								}
+								{
+									position433, tokenIndex433 := position, tokenIndex
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l434
+									}
+									position++
+									goto l433
+								l434:
+									position, tokenIndex = position433, tokenIndex433
+									if c := buffer[position]; c < rune('a') || c > rune('f') {
+										goto l432
+									}
+									position++
+								}
+							l433:
+								goto l431
+							l432:
+								position, tokenIndex = position431, tokenIndex431
+								if !_rules[ruleHexOctet]() {
+									goto l426
+								}
+							}
+						l431:
+							{
+								position435, tokenIndex435 := position, tokenIndex
+								{
+									switch buffer[position] {
+									case '/':
+										if buffer[position] != rune('/') {
+											goto l435
+										}
+										position++
+										break
+									case ':':
+										if buffer[position] != rune(':') {
+											goto l435
+										}
+										position++
+										break
+									case '_':
+										if buffer[position] != rune('_') {
+											goto l435
+										}
+										position++
+										break
+									case '.':
+										if buffer[position] != rune('.') {
+											goto l435
+										}
+										position++
+										break
+									case '-':
+										if buffer[position] != rune('-') {
+											goto l435
+										}
+										position++
+										break
+									case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l435
+										}
+										position++
+										break
+									case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l435
+										}
+										position++
+										break
+									default:
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l435
+										}
+										position++
+										break
+									}
+								}
+
+								goto l426
+							l435:
+								position, tokenIndex = position435, tokenIndex435
+							}
+							add(ruleResourceIdValue, position428)
+						}
+						add(rulePegText, position427)
+					}
+					{
+						add(ruleAction37, position)
+					}
+					goto l314
+				l426:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						position439 := position
+						if buffer[position] != rune('n') {
+							goto l438
+						}
+						position++
+						if buffer[position] != rune('u') {
+							goto l438
+						}
+						position++
+						if buffer[position] != rune('l') {
+							goto l438
+						}
+						position++
+						if buffer[position] != rune('l') {
+							goto l438
+						}
+						position++
+						{
+							position440, tokenIndex440 := position, tokenIndex
+							{
+								switch buffer[position] {
+								case '/':
+									if buffer[position] != rune('/') {
+										goto l440
+									}
+									position++
+									break
+								case ':':
+									if buffer[position] != rune(':') {
+										goto l440
+									}
+									position++
+									break
+								case '_':
+									if buffer[position] != rune('_') {
+										goto l440
+									}
+									position++
+									break
+								case '.':
+									if buffer[position] != rune('.') {
+										goto l440
+									}
+									position++
+									break
+								case '-':
+									if buffer[position] != rune('-') {
+										goto l440
+									}
+									position++
+									break
+								case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l440
+									}
+									position++
+									break
+								case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+									if c := buffer[position]; c < rune('A') || c > rune('Z') {
+										goto l440
+									}
+									position++
+									break
+								default:
+									if c := buffer[position]; c < rune('a') || c > rune('z') {
+										goto l440
+									}
+									position++
+									break
+								}
+							}
+
+							goto l438
+						l440:
+							position, tokenIndex = position440, tokenIndex440
+						}
+						add(ruleNullValue, position439)
+					}
+					{
+						add(ruleAction38, position)
+					}
+					goto l314
+				l438:
+					position, tokenIndex = position314, tokenIndex314
+					{
+						switch buffer[position] {
+						case '#':
+							{
+								position444 := position
+								{
+									position445 := position
+									if buffer[position] != rune('#') {
+										goto l312
+									}
+									position++
+									{
+										switch buffer[position] {
+										case 'A', 'B', 'C', 'D', 'E', 'F':
+											if c := buffer[position]; c < rune('A') || c > rune('F') {
+												goto l312
+											}
+											position++
+											break
+										case 'a', 'b', 'c', 'd', 'e', 'f':
+											if c := buffer[position]; c < rune('a') || c > rune('f') {
+												goto l312
 											}
-											add(rulePegText, position176)
+											position++
+											break
+										default:
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l312
+											}
+											position++
+											break
 										}
-										{
-											add(ruleAction11, position)
+									}
+
+									{
+										switch buffer[position] {
+										case 'A', 'B', 'C', 'D', 'E', 'F':
+											if c := buffer[position]; c < rune('A') || c > rune('F') {
+												goto l312
+											}
+											position++
+											break
+										case 'a', 'b', 'c', 'd', 'e', 'f':
+											if c := buffer[position]; c < rune('a') || c > rune('f') {
+												goto l312
+											}
+											position++
+											break
+										default:
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l312
+											}
+											position++
+											break
 										}
-										goto l140
-									l175:
-										position, tokenIndex = position140, tokenIndex140
-										{
-											switch buffer[position] {
-											case '$':
-												{
-													position182 := position
-													if buffer[position] != rune('$') {
-														goto l73
-													}
-													position++
-													{
-														position183 := position
-														if !_rules[ruleIdentifier]() {
-															goto l73
-														}
-														add(rulePegText, position183)
-													}
-													add(ruleRefValue, position182)
-												}
-												{
-													add(ruleAction7, position)
-												}
-												break
-											case '@':
-												{
-													position185 := position
-													if buffer[position] != rune('@') {
-														goto l73
-													}
-													position++
-													{
-														position186 := position
-														if !_rules[ruleIdentifier]() {
-															goto l73
-														}
-														add(rulePegText, position186)
-													}
-													add(ruleAliasValue, position185)
-												}
-												{
-													add(ruleAction6, position)
-												}
-												break
-											case '{':
-												{
-													position188 := position
-													if buffer[position] != rune('{') {
-														goto l73
-													}
-													position++
-													if !_rules[ruleWhiteSpacing]() {
-														goto l73
-													}
-													{
-														position189 := position
-														if !_rules[ruleIdentifier]() {
-															goto l73
-														}
-														add(rulePegText, position189)
-													}
-													if !_rules[ruleWhiteSpacing]() {
-														goto l73
-													}
-													if buffer[position] != rune('}') {
-														goto l73
-													}
-													position++
-													add(ruleHoleValue, position188)
-												}
-												{
-													add(ruleAction5, position)
-												}
-												break
-											default:
-												{
-													position191 := position
-													{
-														position192 := position
-														{
-															switch buffer[position] {
-															case '/':
-																if buffer[position] != rune('/') {
-																	goto l73
-																}
-																position++
-																break
-															case ':':
-																if buffer[position] != rune(':') {
-																	goto l73
-																}
-																position++
-																break
-															case '_':
-																if buffer[position] != rune('_') {
-																	goto l73
-																}
-																position++
-																break
-															case '.':
-																if buffer[position] != rune('.') {
-																	goto l73
-																}
-																position++
-																break
-															case '-':
-																if buffer[position] != rune('-') {
-																	goto l73
-																}
-																position++
-																break
-															case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-																if c := buffer[position]; c < rune('0') || c > rune('9') {
-																	goto l73
-																}
-																position++
-																break
-															case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
-																if c := buffer[position]; c < rune('A') || c > rune('Z') {
-																	goto l73
-																}
-																position++
-																break
-															default:
-																if c := buffer[position]; c < rune('a') || c > rune('z') {
-																	goto l73
-																}
-																position++
-																break
-															}
-														}
+									}
 
-													l193:
-														{
-															position194, tokenIndex194 := position, tokenIndex
-															{
-																switch buffer[position] {
-																case '/':
-																	if buffer[position] != rune('/') {
-																		goto l194
-																	}
-																	position++
-																	break
-																case ':':
-																	if buffer[position] != rune(':') {
-																		goto l194
-																	}
-																	position++
-																	break
-																case '_':
-																	if buffer[position] != rune('_') {
-																		goto l194
-																	}
-																	position++
-																	break
-																case '.':
-																	if buffer[position] != rune('.') {
-																		goto l194
-																	}
-																	position++
-																	break
-																case '-':
-																	if buffer[position] != rune('-') {
-																		goto l194
-																	}
-																	position++
-																	break
-																case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-																	if c := buffer[position]; c < rune('0') || c > rune('9') {
-																		goto l194
-																	}
-																	position++
-																	break
-																case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
-																	if c := buffer[position]; c < rune('A') || c > rune('Z') {
-																		goto l194
-																	}
-																	position++
-																	break
-																default:
-																	if c := buffer[position]; c < rune('a') || c > rune('z') {
-																		goto l194
-																	}
-																	position++
-																	break
-																}
-															}
+									{
+										switch buffer[position] {
+										case 'A', 'B', 'C', 'D', 'E', 'F':
+											if c := buffer[position]; c < rune('A') || c > rune('F') {
+												goto l312
+											}
+											position++
+											break
+										case 'a', 'b', 'c', 'd', 'e', 'f':
+											if c := buffer[position]; c < rune('a') || c > rune('f') {
+												goto l312
+											}
+											position++
+											break
+										default:
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l312
+											}
+											position++
+											break
+										}
+									}
+
+									{
+										switch buffer[position] {
+										case 'A', 'B', 'C', 'D', 'E', 'F':
+											if c := buffer[position]; c < rune('A') || c > rune('F') {
+												goto l312
+											}
+											position++
+											break
+										case 'a', 'b', 'c', 'd', 'e', 'f':
+											if c := buffer[position]; c < rune('a') || c > rune('f') {
+												goto l312
+											}
+											position++
+											break
+										default:
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l312
+											}
+											position++
+											break
+										}
+									}
+
+									{
+										switch buffer[position] {
+										case 'A', 'B', 'C', 'D', 'E', 'F':
+											if c := buffer[position]; c < rune('A') || c > rune('F') {
+												goto l312
+											}
+											position++
+											break
+										case 'a', 'b', 'c', 'd', 'e', 'f':
+											if c := buffer[position]; c < rune('a') || c > rune('f') {
+												goto l312
+											}
+											position++
+											break
+										default:
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l312
+											}
+											position++
+											break
+										}
+									}
 
-															goto l193
-														l194:
-															position, tokenIndex = position194, tokenIndex194
-														}
-														add(ruleStringValue, position192)
-													}
-													add(rulePegText, position191)
+									{
+										switch buffer[position] {
+										case 'A', 'B', 'C', 'D', 'E', 'F':
+											if c := buffer[position]; c < rune('A') || c > rune('F') {
+												goto l312
+											}
+											position++
+											break
+										case 'a', 'b', 'c', 'd', 'e', 'f':
+											if c := buffer[position]; c < rune('a') || c > rune('f') {
+												goto l312
+											}
+											position++
+											break
+										default:
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l312
+											}
+											position++
+											break
+										}
+									}
+
+									{
+										position452, tokenIndex452 := position, tokenIndex
+										{
+											switch buffer[position] {
+											case '/':
+												if buffer[position] != rune('/') {
+													goto l452
 												}
-												{
-													add(ruleAction12, position)
+												position++
+												break
+											case ':':
+												if buffer[position] != rune(':') {
+													goto l452
+												}
+												position++
+												break
+											case '_':
+												if buffer[position] != rune('_') {
+													goto l452
+												}
+												position++
+												break
+											case '.':
+												if buffer[position] != rune('.') {
+													goto l452
+												}
+												position++
+												break
+											case '-':
+												if buffer[position] != rune('-') {
+													goto l452
+												}
+												position++
+												break
+											case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+												if c := buffer[position]; c < rune('0') || c > rune('9') {
+													goto l452
+												}
+												position++
+												break
+											case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+												if c := buffer[position]; c < rune('A') || c > rune('Z') {
+													goto l452
 												}
+												position++
+												break
+											default:
+												if c := buffer[position]; c < rune('a') || c > rune('z') {
+													goto l452
+												}
+												position++
 												break
 											}
 										}
 
+										goto l312
+									l452:
+										position, tokenIndex = position452, tokenIndex452
+									}
+									add(ruleColorValue, position445)
+								}
+								add(rulePegText, position444)
+							}
+							{
+								add(ruleAction33, position)
+							}
+							break
+						case '$':
+							{
+								position455 := position
+								if buffer[position] != rune('$') {
+									goto l312
+								}
+								position++
+								{
+									position456 := position
+									{
+										position457, tokenIndex457 := position, tokenIndex
+										if !_rules[ruleQuotedName]() {
+											goto l458
+										}
+										goto l457
+									l458:
+										position, tokenIndex = position457, tokenIndex457
+										if !_rules[ruleName]() {
+											goto l312
+										}
+									}
+								l457:
+									add(rulePegText, position456)
+								}
+								add(ruleRefValue, position455)
+							}
+							{
+								add(ruleAction27, position)
+							}
+							break
+						case '@':
+							{
+								position460 := position
+								if buffer[position] != rune('@') {
+									goto l312
+								}
+								position++
+								{
+									position461 := position
+									{
+										position462, tokenIndex462 := position, tokenIndex
+										if !_rules[ruleQuotedName]() {
+											goto l463
+										}
+										goto l462
+									l463:
+										position, tokenIndex = position462, tokenIndex462
+										if !_rules[ruleName]() {
+											goto l312
+										}
+									l464:
+										{
+											position465, tokenIndex465 := position, tokenIndex
+											{
+												position466, tokenIndex466 := position, tokenIndex
+												if buffer[position] != rune('/') {
+													goto l467
+												}
+												position++
+												goto l466
+											l467:
+												position, tokenIndex = position466, tokenIndex466
+												if buffer[position] != rune(':') {
+													goto l465
+												}
+												position++
+											}
+										l466:
+											if !_rules[ruleName]() {
+												goto l465
+											}
+											goto l464
+										l465:
+											position, tokenIndex = position465, tokenIndex465
+										}
+									}
+								l462:
+									add(rulePegText, position461)
+								}
+								add(ruleAliasValue, position460)
+							}
+							{
+								add(ruleAction26, position)
+							}
+							break
+						case '"':
+							if !_rules[ruleDoubleQuotedValue]() {
+								goto l312
+							}
+							{
+								add(ruleAction25, position)
+							}
+							break
+						case '\'':
+							if !_rules[ruleSingleQuotedValue]() {
+								goto l312
+							}
+							{
+								add(ruleAction24, position)
+							}
+							break
+						case '{':
+							{
+								position471 := position
+								if buffer[position] != rune('{') {
+									goto l312
+								}
+								position++
+								if !_rules[ruleWhiteSpacing]() {
+									goto l312
+								}
+								{
+									position472 := position
+									{
+										position473, tokenIndex473 := position, tokenIndex
+										if !_rules[ruleQuotedName]() {
+											goto l474
+										}
+										goto l473
+									l474:
+										position, tokenIndex = position473, tokenIndex473
+										if !_rules[ruleName]() {
+											goto l312
+										}
 									}
-								l140:
-									add(ruleValue, position139)
+								l473:
+									add(rulePegText, position472)
+								}
+								{
+									add(ruleAction43, position)
 								}
 								if !_rules[ruleWhiteSpacing]() {
-									goto l73
+									goto l312
+								}
+								{
+									position476, tokenIndex476 := position, tokenIndex
+									if buffer[position] != rune(':') {
+										goto l476
+									}
+									position++
+									if !_rules[ruleWhiteSpacing]() {
+										goto l476
+									}
+									{
+										position478 := position
+										if !_rules[ruleIdentifier]() {
+											goto l476
+										}
+										add(rulePegText, position478)
+									}
+									{
+										add(ruleAction44, position)
+									}
+									if !_rules[ruleWhiteSpacing]() {
+										goto l476
+									}
+									goto l477
+								l476:
+									position, tokenIndex = position476, tokenIndex476
+								}
+							l477:
+								if buffer[position] != rune('}') {
+									goto l312
+								}
+								position++
+								add(ruleHoleValue, position471)
+							}
+							break
+						default:
+							{
+								position480 := position
+								if !_rules[ruleStringValue]() {
+									goto l312
+								}
+								add(rulePegText, position480)
+							}
+							{
+								add(ruleAction39, position)
+							}
+							break
+						}
+					}
+
+				}
+			l314:
+				add(ruleItemValue, position313)
+			}
+			return true
+		l312:
+			position, tokenIndex = position312, tokenIndex312
+			return false
+		},
+		/* 24 StringValue <- <((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		func() bool {
+			position482, tokenIndex482 := position, tokenIndex
+			{
+				position483 := position
+				{
+					switch buffer[position] {
+					case '/':
+						if buffer[position] != rune('/') {
+							goto l482
+						}
+						position++
+						break
+					case ':':
+						if buffer[position] != rune(':') {
+							goto l482
+						}
+						position++
+						break
+					case '_':
+						if buffer[position] != rune('_') {
+							goto l482
+						}
+						position++
+						break
+					case '.':
+						if buffer[position] != rune('.') {
+							goto l482
+						}
+						position++
+						break
+					case '-':
+						if buffer[position] != rune('-') {
+							goto l482
+						}
+						position++
+						break
+					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l482
+						}
+						position++
+						break
+					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l482
+						}
+						position++
+						break
+					default:
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l482
+						}
+						position++
+						break
+					}
+				}
+
+			l484:
+				{
+					position485, tokenIndex485 := position, tokenIndex
+					{
+						switch buffer[position] {
+						case '/':
+							if buffer[position] != rune('/') {
+								goto l485
+							}
+							position++
+							break
+						case ':':
+							if buffer[position] != rune(':') {
+								goto l485
+							}
+							position++
+							break
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l485
+							}
+							position++
+							break
+						case '.':
+							if buffer[position] != rune('.') {
+								goto l485
+							}
+							position++
+							break
+						case '-':
+							if buffer[position] != rune('-') {
+								goto l485
+							}
+							position++
+							break
+						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l485
+							}
+							position++
+							break
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l485
+							}
+							position++
+							break
+						default:
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l485
+							}
+							position++
+							break
+						}
+					}
+
+					goto l484
+				l485:
+					position, tokenIndex = position485, tokenIndex485
+				}
+				add(ruleStringValue, position483)
+			}
+			return true
+		l482:
+			position, tokenIndex = position482, tokenIndex482
+			return false
+		},
+		/* 25 ListValue <- <(StringValue (',' StringValue)+)> */
+		nil,
+		/* 26 BracketListValue <- <('[' Action40 WhiteSpacing (ListItem (WhiteSpacing ',' WhiteSpacing ListItem)* (WhiteSpacing ',')?)? WhiteSpacing ']' Action41)> */
+		nil,
+		/* 27 ListItem <- <(Action42 ItemValue)> */
+		func() bool {
+			position490, tokenIndex490 := position, tokenIndex
+			{
+				position491 := position
+				{
+					add(ruleAction42, position)
+				}
+				if !_rules[ruleItemValue]() {
+					goto l490
+				}
+				add(ruleListItem, position491)
+			}
+			return true
+		l490:
+			position, tokenIndex = position490, tokenIndex490
+			return false
+		},
+		/* 28 SingleQuotedValue <- <('\'' <(!'\'' .)*> '\'')> */
+		func() bool {
+			position493, tokenIndex493 := position, tokenIndex
+			{
+				position494 := position
+				if buffer[position] != rune('\'') {
+					goto l493
+				}
+				position++
+				{
+					position495 := position
+				l496:
+					{
+						position497, tokenIndex497 := position, tokenIndex
+						{
+							position498, tokenIndex498 := position, tokenIndex
+							if buffer[position] != rune('\'') {
+								goto l498
+							}
+							position++
+							goto l497
+						l498:
+							position, tokenIndex = position498, tokenIndex498
+						}
+						if !matchDot() {
+							goto l497
+						}
+						goto l496
+					l497:
+						position, tokenIndex = position497, tokenIndex497
+					}
+					add(rulePegText, position495)
+				}
+				if buffer[position] != rune('\'') {
+					goto l493
+				}
+				position++
+				add(ruleSingleQuotedValue, position494)
+			}
+			return true
+		l493:
+			position, tokenIndex = position493, tokenIndex493
+			return false
+		},
+		/* 29 DoubleQuotedValue <- <('"' <(('\\' .) / (!'"' .))*> '"')> */
+		func() bool {
+			position499, tokenIndex499 := position, tokenIndex
+			{
+				position500 := position
+				if buffer[position] != rune('"') {
+					goto l499
+				}
+				position++
+				{
+					position501 := position
+				l502:
+					{
+						position503, tokenIndex503 := position, tokenIndex
+						{
+							position504, tokenIndex504 := position, tokenIndex
+							if buffer[position] != rune('\\') {
+								goto l505
+							}
+							position++
+							if !matchDot() {
+								goto l505
+							}
+							goto l504
+						l505:
+							position, tokenIndex = position504, tokenIndex504
+							{
+								position506, tokenIndex506 := position, tokenIndex
+								if buffer[position] != rune('"') {
+									goto l506
 								}
-								add(ruleParam, position136)
+								position++
+								goto l503
+							l506:
+								position, tokenIndex = position506, tokenIndex506
+							}
+							if !matchDot() {
+								goto l503
 							}
-							goto l72
-						l73:
-							position, tokenIndex = position73, tokenIndex73
 						}
-						add(ruleParams, position71)
+					l504:
+						goto l502
+					l503:
+						position, tokenIndex = position503, tokenIndex503
 					}
-					goto l70
-				l69:
-					position, tokenIndex = position69, tokenIndex69
+					add(rulePegText, position501)
 				}
-			l70:
-				{
-					add(ruleAction3, position)
+				if buffer[position] != rune('"') {
+					goto l499
 				}
-				add(ruleExpr, position49)
+				position++
+				add(ruleDoubleQuotedValue, position500)
 			}
 			return true
-		l48:
-			position, tokenIndex = position48, tokenIndex48
+		l499:
+			position, tokenIndex = position499, tokenIndex499
 			return false
 		},
-		/* 6 Params <- <Param+> */
+		/* 30 HeredocValue <- <(HeredocStart (('\r' '\n') / '\n') &((!HeredocEnd .)* HeredocEnd) <(!HeredocEnd .)*> HeredocEnd)> */
 		nil,
-		/* 7 Param <- <(<Identifier> Action4 Equal Value WhiteSpacing)> */
+		/* 31 HeredocStart <- <('<' '<' 'E' 'O' 'F')> */
 		nil,
-		/* 8 Identifier <- <((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		/* 32 HeredocEnd <- <('\n' ('E' 'O' 'F') !((&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
 		func() bool {
-			position201, tokenIndex201 := position, tokenIndex
+			position509, tokenIndex509 := position, tokenIndex
 			{
-				position202 := position
-				{
-					switch buffer[position] {
-					case '.':
-						if buffer[position] != rune('.') {
-							goto l201
-						}
-						position++
-						break
-					case '_':
-						if buffer[position] != rune('_') {
-							goto l201
-						}
-						position++
-						break
-					case '-':
-						if buffer[position] != rune('-') {
-							goto l201
-						}
-						position++
-						break
-					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
-						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l201
-						}
-						position++
-						break
-					default:
-						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l201
-						}
-						position++
-						break
-					}
+				position510 := position
+				if buffer[position] != rune('\n') {
+					goto l509
 				}
-
-			l203:
+				position++
+				if buffer[position] != rune('E') {
+					goto l509
+				}
+				position++
+				if buffer[position] != rune('O') {
+					goto l509
+				}
+				position++
+				if buffer[position] != rune('F') {
+					goto l509
+				}
+				position++
 				{
-					position204, tokenIndex204 := position, tokenIndex
+					position511, tokenIndex511 := position, tokenIndex
 					{
 						switch buffer[position] {
-						case '.':
-							if buffer[position] != rune('.') {
-								goto l204
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l511
 							}
 							position++
 							break
-						case '_':
-							if buffer[position] != rune('_') {
-								goto l204
+						case '.':
+							if buffer[position] != rune('.') {
+								goto l511
 							}
 							position++
 							break
 						case '-':
 							if buffer[position] != rune('-') {
-								goto l204
+								goto l511
+							}
+							position++
+							break
+						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l511
 							}
 							position++
 							break
 						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l204
+								goto l511
 							}
 							position++
 							break
 						default:
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l204
+								goto l511
 							}
 							position++
 							break
 						}
 					}
 
-					goto l203
-				l204:
-					position, tokenIndex = position204, tokenIndex204
+					goto l509
+				l511:
+					position, tokenIndex = position511, tokenIndex511
+				}
+				add(ruleHeredocEnd, position510)
+			}
+			return true
+		l509:
+			position, tokenIndex = position509, tokenIndex509
+			return false
+		},
+		/* 33 CidrValue <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+ '/' [0-9]+)> */
+		nil,
+		/* 34 IpValue <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+)> */
+		nil,
+		/* 35 IntValue <- <((('0' ('o' / 'O')) / [0-9]) ([0-9] / '_')*)> */
+		nil,
+		/* 36 HexValue <- <('0' ('x' / 'X') ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]))+ !((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
+		nil,
+		/* 37 FloatValue <- <([0-9]+ (('.' [0-9]+ Exponent?) / Exponent) !((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
+		nil,
+		/* 38 Exponent <- <(('e' / 'E') ('+' / '-')? [0-9]+)> */
+		func() bool {
+			position518, tokenIndex518 := position, tokenIndex
+			{
+				position519 := position
+				{
+					position520, tokenIndex520 := position, tokenIndex
+					if buffer[position] != rune('e') {
+						goto l521
+					}
+					position++
+					goto l520
+				l521:
+					position, tokenIndex = position520, tokenIndex520
+					if buffer[position] != rune('E') {
+						goto l518
+					}
+					position++
+				}
+			l520:
+				{
+					position522, tokenIndex522 := position, tokenIndex
+					{
+						position524, tokenIndex524 := position, tokenIndex
+						if buffer[position] != rune('+') {
+							goto l525
+						}
+						position++
+						goto l524
+					l525:
+						position, tokenIndex = position524, tokenIndex524
+						if buffer[position] != rune('-') {
+							goto l522
+						}
+						position++
+					}
+				l524:
+					goto l523
+				l522:
+					position, tokenIndex = position522, tokenIndex522
+				}
+			l523:
+				if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l518
+				}
+				position++
+			l526:
+				{
+					position527, tokenIndex527 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l527
+					}
+					position++
+					goto l526
+				l527:
+					position, tokenIndex = position527, tokenIndex527
+				}
+				add(ruleExponent, position519)
+			}
+			return true
+		l518:
+			position, tokenIndex = position518, tokenIndex518
+			return false
+		},
+		/* 39 IntRangeValue <- <(RangeBound '-' RangeBound !((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
+		nil,
+		/* 40 RangeBound <- <('-'? [0-9]+ ('.' [0-9]+)?)> */
+		func() bool {
+			position529, tokenIndex529 := position, tokenIndex
+			{
+				position530 := position
+				{
+					position531, tokenIndex531 := position, tokenIndex
+					if buffer[position] != rune('-') {
+						goto l531
+					}
+					position++
+					goto l532
+				l531:
+					position, tokenIndex = position531, tokenIndex531
+				}
+			l532:
+				if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l529
+				}
+				position++
+			l533:
+				{
+					position534, tokenIndex534 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l534
+					}
+					position++
+					goto l533
+				l534:
+					position, tokenIndex = position534, tokenIndex534
+				}
+				{
+					position535, tokenIndex535 := position, tokenIndex
+					if buffer[position] != rune('.') {
+						goto l535
+					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l535
+					}
+					position++
+				l537:
+					{
+						position538, tokenIndex538 := position, tokenIndex
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l538
+						}
+						position++
+						goto l537
+					l538:
+						position, tokenIndex = position538, tokenIndex538
+					}
+					goto l536
+				l535:
+					position, tokenIndex = position535, tokenIndex535
+				}
+			l536:
+				add(ruleRangeBound, position530)
+			}
+			return true
+		l529:
+			position, tokenIndex = position529, tokenIndex529
+			return false
+		},
+		/* 41 ResourceIdValue <- <([a-z]+ '-' ((HexOctet HexOctet ([0-9] / [a-f])) / HexOctet) !((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
+		nil,
+		/* 42 HexOctet <- <(([0-9] / [a-f]) ([0-9] / [a-f]) ([0-9] / [a-f]) ([0-9] / [a-f]) ([0-9] / [a-f]) ([0-9] / [a-f]) ([0-9] / [a-f]) ([0-9] / [a-f]))> */
+		func() bool {
+			position540, tokenIndex540 := position, tokenIndex
+			{
+				position541 := position
+				{
+					position542, tokenIndex542 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l543
+					}
+					position++
+					goto l542
+				l543:
+					position, tokenIndex = position542, tokenIndex542
+					if c := buffer[position]; c < rune('a') || c > rune('f') {
+						goto l540
+					}
+					position++
+				}
+			l542:
+				{
+					position544, tokenIndex544 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l545
+					}
+					position++
+					goto l544
+				l545:
+					position, tokenIndex = position544, tokenIndex544
+					if c := buffer[position]; c < rune('a') || c > rune('f') {
+						goto l540
+					}
+					position++
+				}
+			l544:
+				{
+					position546, tokenIndex546 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l547
+					}
+					position++
+					goto l546
+				l547:
+					position, tokenIndex = position546, tokenIndex546
+					if c := buffer[position]; c < rune('a') || c > rune('f') {
+						goto l540
+					}
+					position++
+				}
+			l546:
+				{
+					position548, tokenIndex548 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l549
+					}
+					position++
+					goto l548
+				l549:
+					position, tokenIndex = position548, tokenIndex548
+					if c := buffer[position]; c < rune('a') || c > rune('f') {
+						goto l540
+					}
+					position++
+				}
+			l548:
+				{
+					position550, tokenIndex550 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l551
+					}
+					position++
+					goto l550
+				l551:
+					position, tokenIndex = position550, tokenIndex550
+					if c := buffer[position]; c < rune('a') || c > rune('f') {
+						goto l540
+					}
+					position++
+				}
+			l550:
+				{
+					position552, tokenIndex552 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l553
+					}
+					position++
+					goto l552
+				l553:
+					position, tokenIndex = position552, tokenIndex552
+					if c := buffer[position]; c < rune('a') || c > rune('f') {
+						goto l540
+					}
+					position++
+				}
+			l552:
+				{
+					position554, tokenIndex554 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l555
+					}
+					position++
+					goto l554
+				l555:
+					position, tokenIndex = position554, tokenIndex554
+					if c := buffer[position]; c < rune('a') || c > rune('f') {
+						goto l540
+					}
+					position++
 				}
-				add(ruleIdentifier, position202)
+			l554:
+				{
+					position556, tokenIndex556 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l557
+					}
+					position++
+					goto l556
+				l557:
+					position, tokenIndex = position556, tokenIndex556
+					if c := buffer[position]; c < rune('a') || c > rune('f') {
+						goto l540
+					}
+					position++
+				}
+			l556:
+				add(ruleHexOctet, position541)
 			}
 			return true
-		l201:
-			position, tokenIndex = position201, tokenIndex201
+		l540:
+			position, tokenIndex = position540, tokenIndex540
 			return false
 		},
-		/* 9 Value <- <((<CidrValue> Action8) / (<IpValue> Action9) / (<IntRangeValue> Action10) / (<IntValue> Action11) / ((&('$') (RefValue Action7)) | (&('@') (AliasValue Action6)) | (&('{') (HoleValue Action5)) | (&('-' | '.' | '/' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | ':' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') (<StringValue> Action12))))> */
+		/* 43 SizeValue <- <([0-9]+ ((&('T' | 't') ('t' / 'T')) | (&('G' | 'g') ('g' / 'G')) | (&('K') 'K') | (&('k') 'k') | (&('M' | 'm') ('m' / 'M'))) ('b' / 'B') !((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
+		nil,
+		/* 44 ColorValue <- <('#' ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9])) ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9])) ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9])) ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9])) ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9])) ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9])) !((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
+		nil,
+		/* 45 PercentOfValue <- <([0-9]+ ('.' [0-9]+)? ('%' 'o' 'f' '$') (QuotedName / Name))> */
 		nil,
-		/* 10 StringValue <- <((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		/* 46 NullValue <- <('n' 'u' 'l' 'l' !((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
 		nil,
-		/* 11 CidrValue <- <([0-9]+ . [0-9]+ . [0-9]+ . [0-9]+ '/' [0-9]+)> */
+		/* 47 ArnValue <- <('a' 'r' 'n' ':' ([a-z] / '-')+ ':' ((&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+ ':' ((&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))* ':' ((&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))* ':' ((&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+)> */
 		nil,
-		/* 12 IpValue <- <([0-9]+ . [0-9]+ . [0-9]+ . [0-9]+)> */
+		/* 48 RefValue <- <('$' <(QuotedName / Name)>)> */
 		nil,
-		/* 13 IntValue <- <[0-9]+> */
+		/* 49 AliasValue <- <('@' <(QuotedName / (Name (('/' / ':') Name)*))>)> */
 		nil,
-		/* 14 IntRangeValue <- <([0-9]+ '-' [0-9]+)> */
+		/* 50 EnvValue <- <('$' '{' 'E' 'N' 'V' ':' <(((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])) ((&('_') '_') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> '}')> */
 		nil,
-		/* 15 RefValue <- <('$' <Identifier>)> */
+		/* 51 HoleValue <- <('{' WhiteSpacing <(QuotedName / Name)> Action43 WhiteSpacing (':' WhiteSpacing <Identifier> Action44 WhiteSpacing)? '}')> */
 		nil,
-		/* 16 AliasValue <- <('@' <Identifier>)> */
+		/* 52 Comment <- <((<('#' (!EndOfLine .)*)> Action45) / (<('/' '/' (!EndOfLine .)*)> Action46) / BlockComment)> */
 		nil,
-		/* 17 HoleValue <- <('{' WhiteSpacing <Identifier> WhiteSpacing '}')> */
+		/* 53 InlineComment <- <(('#' (!EndOfLine .)*) / ('/' '/' (!EndOfLine .)*))> */
 		nil,
-		/* 18 Comment <- <(('#' (!EndOfLine .)*) / ('/' '/' (!EndOfLine .)* Action13))> */
+		/* 54 BlockComment <- <(BlockCommentStart (!('*' '/') .)* ('*' '/'))> */
 		nil,
-		/* 19 Spacing <- <Space*> */
+		/* 55 BlockCommentStart <- <('/' '*')> */
+		nil,
+		/* 56 Spacing <- <Space*> */
 		func() bool {
 			{
-				position218 := position
-			l219:
+				position572 := position
+			l573:
 				{
-					position220, tokenIndex220 := position, tokenIndex
-					{
-						position221 := position
-						{
-							position222, tokenIndex222 := position, tokenIndex
-							if !_rules[ruleWhitespace]() {
-								goto l223
-							}
-							goto l222
-						l223:
-							position, tokenIndex = position222, tokenIndex222
-							if !_rules[ruleEndOfLine]() {
-								goto l220
-							}
-						}
-					l222:
-						add(ruleSpace, position221)
+					position574, tokenIndex574 := position, tokenIndex
+					if !_rules[ruleSpace]() {
+						goto l574
 					}
-					goto l219
-				l220:
-					position, tokenIndex = position220, tokenIndex220
+					goto l573
+				l574:
+					position, tokenIndex = position574, tokenIndex574
 				}
-				add(ruleSpacing, position218)
+				add(ruleSpacing, position572)
 			}
 			return true
 		},
-		/* 20 WhiteSpacing <- <Whitespace*> */
+		/* 57 WhiteSpacing <- <Whitespace*> */
 		func() bool {
 			{
-				position225 := position
-			l226:
+				position576 := position
+			l577:
 				{
-					position227, tokenIndex227 := position, tokenIndex
+					position578, tokenIndex578 := position, tokenIndex
 					if !_rules[ruleWhitespace]() {
-						goto l227
+						goto l578
 					}
-					goto l226
-				l227:
-					position, tokenIndex = position227, tokenIndex227
+					goto l577
+				l578:
+					position, tokenIndex = position578, tokenIndex578
 				}
-				add(ruleWhiteSpacing, position225)
+				add(ruleWhiteSpacing, position576)
 			}
 			return true
 		},
-		/* 21 MustWhiteSpacing <- <Whitespace+> */
+		/* 58 MustWhiteSpacing <- <Whitespace+> */
 		func() bool {
-			position228, tokenIndex228 := position, tokenIndex
+			position579, tokenIndex579 := position, tokenIndex
 			{
-				position229 := position
+				position580 := position
 				if !_rules[ruleWhitespace]() {
-					goto l228
+					goto l579
 				}
-			l230:
+			l581:
 				{
-					position231, tokenIndex231 := position, tokenIndex
+					position582, tokenIndex582 := position, tokenIndex
 					if !_rules[ruleWhitespace]() {
-						goto l231
+						goto l582
 					}
-					goto l230
-				l231:
-					position, tokenIndex = position231, tokenIndex231
+					goto l581
+				l582:
+					position, tokenIndex = position582, tokenIndex582
 				}
-				add(ruleMustWhiteSpacing, position229)
+				add(ruleMustWhiteSpacing, position580)
 			}
 			return true
-		l228:
-			position, tokenIndex = position228, tokenIndex228
+		l579:
+			position, tokenIndex = position579, tokenIndex579
 			return false
 		},
-		/* 22 Equal <- <(Spacing '=' Spacing)> */
+		/* 59 Equal <- <(Spacing '=' Spacing)> */
 		func() bool {
-			position232, tokenIndex232 := position, tokenIndex
+			position583, tokenIndex583 := position, tokenIndex
 			{
-				position233 := position
+				position584 := position
 				if !_rules[ruleSpacing]() {
-					goto l232
+					goto l583
 				}
 				if buffer[position] != rune('=') {
-					goto l232
+					goto l583
 				}
 				position++
 				if !_rules[ruleSpacing]() {
-					goto l232
+					goto l583
 				}
-				add(ruleEqual, position233)
+				add(ruleEqual, position584)
 			}
 			return true
-		l232:
-			position, tokenIndex = position232, tokenIndex232
+		l583:
+			position, tokenIndex = position583, tokenIndex583
 			return false
 		},
-		/* 23 Space <- <(Whitespace / EndOfLine)> */
-		nil,
-		/* 24 Whitespace <- <(' ' / '\t')> */
+		/* 60 Space <- <(Whitespace / EndOfLine)> */
 		func() bool {
-			position235, tokenIndex235 := position, tokenIndex
+			position585, tokenIndex585 := position, tokenIndex
 			{
-				position236 := position
+				position586 := position
 				{
-					position237, tokenIndex237 := position, tokenIndex
-					if buffer[position] != rune(' ') {
-						goto l238
+					position587, tokenIndex587 := position, tokenIndex
+					if !_rules[ruleWhitespace]() {
+						goto l588
 					}
-					position++
-					goto l237
-				l238:
-					position, tokenIndex = position237, tokenIndex237
-					if buffer[position] != rune('\t') {
-						goto l235
+					goto l587
+				l588:
+					position, tokenIndex = position587, tokenIndex587
+					if !_rules[ruleEndOfLine]() {
+						goto l585
+					}
+				}
+			l587:
+				add(ruleSpace, position586)
+			}
+			return true
+		l585:
+			position, tokenIndex = position585, tokenIndex585
+			return false
+		},
+		/* 61 Whitespace <- <((&('\\') LineContinuation) | (&('\t') '\t') | (&(' ') ' '))> */
+		func() bool {
+			position589, tokenIndex589 := position, tokenIndex
+			{
+				position590 := position
+				{
+					switch buffer[position] {
+					case '\\':
+						{
+							position592 := position
+							if buffer[position] != rune('\\') {
+								goto l589
+							}
+							position++
+							if !_rules[ruleEndOfLine]() {
+								goto l589
+							}
+							add(ruleLineContinuation, position592)
+						}
+						break
+					case '\t':
+						if buffer[position] != rune('\t') {
+							goto l589
+						}
+						position++
+						break
+					default:
+						if buffer[position] != rune(' ') {
+							goto l589
+						}
+						position++
+						break
 					}
-					position++
 				}
-			l237:
-				add(ruleWhitespace, position236)
+
+				add(ruleWhitespace, position590)
 			}
 			return true
-		l235:
-			position, tokenIndex = position235, tokenIndex235
+		l589:
+			position, tokenIndex = position589, tokenIndex589
 			return false
 		},
-		/* 25 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
+		/* 62 LineContinuation <- <('\\' EndOfLine)> */
+		nil,
+		/* 63 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
 		func() bool {
-			position239, tokenIndex239 := position, tokenIndex
+			position594, tokenIndex594 := position, tokenIndex
 			{
-				position240 := position
+				position595 := position
 				{
-					position241, tokenIndex241 := position, tokenIndex
+					position596, tokenIndex596 := position, tokenIndex
 					if buffer[position] != rune('\r') {
-						goto l242
+						goto l597
 					}
 					position++
 					if buffer[position] != rune('\n') {
-						goto l242
+						goto l597
 					}
 					position++
-					goto l241
-				l242:
-					position, tokenIndex = position241, tokenIndex241
+					goto l596
+				l597:
+					position, tokenIndex = position596, tokenIndex596
 					if buffer[position] != rune('\n') {
-						goto l243
+						goto l598
 					}
 					position++
-					goto l241
-				l243:
-					position, tokenIndex = position241, tokenIndex241
+					goto l596
+				l598:
+					position, tokenIndex = position596, tokenIndex596
 					if buffer[position] != rune('\r') {
-						goto l239
+						goto l594
 					}
 					position++
 				}
-			l241:
-				add(ruleEndOfLine, position240)
+			l596:
+				add(ruleEndOfLine, position595)
 			}
 			return true
-		l239:
-			position, tokenIndex = position239, tokenIndex239
+		l594:
+			position, tokenIndex = position594, tokenIndex594
 			return false
 		},
-		/* 26 EndOfFile <- <!.> */
+		/* 64 EndOfFile <- <!.> */
+		nil,
+		nil,
+		/* 67 Action0 <- <{ p.StartWith(); p.markLine(begin) }> */
+		nil,
+		/* 68 Action1 <- <{ p.LineDone() }> */
+		nil,
+		/* 69 Action2 <- <{ p.EndWith() }> */
+		nil,
+		/* 70 Action3 <- <{ p.AddInclude(text); p.markLine(begin); p.LineDone() }> */
+		nil,
+		/* 71 Action4 <- <{ p.AddVarIdentifier(text); p.markLine(begin) }> */
+		nil,
+		/* 72 Action5 <- <{ p.EndVar() }> */
+		nil,
+		/* 73 Action6 <- <{ p.NegateGuard() }> */
+		nil,
+		/* 74 Action7 <- <{ p.AddGuardHole(text) }> */
+		nil,
+		/* 75 Action8 <- <{ p.AddGuardOp(text) }> */
+		nil,
+		/* 76 Action9 <- <{ p.AddGuardOperand(text) }> */
+		nil,
+		/* 77 Action10 <- <{ p.AddGuardValue(text) }> */
+		nil,
+		/* 78 Action11 <- <{ p.AddDeclarationIdentifier(text); p.markLine(begin) }> */
+		nil,
+		/* 79 Action12 <- <{ p.AddDeclarationExtraIdentifier(text) }> */
+		nil,
+		/* 80 Action13 <- <{ p.AddAction(text); p.markLine(begin) }> */
+		nil,
+		/* 81 Action14 <- <{ p.AddEntity(text) }> */
+		nil,
+		/* 82 Action15 <- <{ p.LineDone() }> */
+		nil,
+		/* 83 Action16 <- <{ p.AddParamKey(text) }> */
+		nil,
+		/* 84 Action17 <- <{ p.AddParamRegexValue(text) }> */
+		nil,
+		/* 85 Action18 <- <{ p.AddModifier(text) }> */
+		nil,
+		/* 86 Action19 <- <{ p.AddFlag(text) }> */
+		nil,
+		/* 87 Action20 <- <{ p.AddParamHeredocValue(text) }> */
+		nil,
+		/* 88 Action21 <- <{ p.AddParamEnvValue(text) }> */
+		nil,
+		/* 89 Action22 <- <{ p.AddParamListValue(text) }> */
+		nil,
+		/* 90 Action23 <- <{ p.AddParamPercentOfValue(text) }> */
+		nil,
+		/* 91 Action24 <- <{ p.AddParamValue(text) }> */
+		nil,
+		/* 92 Action25 <- <{ p.AddParamQuotedValue(text) }> */
+		nil,
+		/* 93 Action26 <- <{  p.AddParamAliasValue(text) }> */
+		nil,
+		/* 94 Action27 <- <{  p.AddParamRefValue(text) }> */
+		nil,
+		/* 95 Action28 <- <{ p.AddParamCidrValue(text) }> */
+		nil,
+		/* 96 Action29 <- <{ p.AddParamIpValue(text) }> */
+		nil,
+		/* 97 Action30 <- <{ p.AddParamHexValue(text) }> */
+		nil,
+		/* 98 Action31 <- <{ p.AddParamIntRangeValue(text) }> */
 		nil,
+		/* 99 Action32 <- <{ p.AddParamSizeValue(text) }> */
 		nil,
-		/* 29 Action0 <- <{ p.AddDeclarationIdentifier(text) }> */
+		/* 100 Action33 <- <{ p.AddParamColorValue(text) }> */
 		nil,
-		/* 30 Action1 <- <{ p.AddAction(text) }> */
+		/* 101 Action34 <- <{ p.AddParamFloatValue(text) }> */
 		nil,
-		/* 31 Action2 <- <{ p.AddEntity(text) }> */
+		/* 102 Action35 <- <{ p.AddParamIntValue(text) }> */
 		nil,
-		/* 32 Action3 <- <{ p.LineDone() }> */
+		/* 103 Action36 <- <{ p.AddParamArnValue(text) }> */
 		nil,
-		/* 33 Action4 <- <{ p.AddParamKey(text) }> */
+		/* 104 Action37 <- <{ p.AddParamResourceIdValue(text) }> */
 		nil,
-		/* 34 Action5 <- <{  p.AddParamHoleValue(text) }> */
+		/* 105 Action38 <- <{ p.AddParamNullValue() }> */
 		nil,
-		/* 35 Action6 <- <{  p.AddParamAliasValue(text) }> */
+		/* 106 Action39 <- <{ p.AddParamValue(text) }> */
 		nil,
-		/* 36 Action7 <- <{  p.AddParamRefValue(text) }> */
+		/* 107 Action40 <- <{ p.StartList() }> */
 		nil,
-		/* 37 Action8 <- <{ p.AddParamCidrValue(text) }> */
+		/* 108 Action41 <- <{ p.EndList() }> */
 		nil,
-		/* 38 Action9 <- <{ p.AddParamIpValue(text) }> */
+		/* 109 Action42 <- <{ p.NextListItem() }> */
 		nil,
-		/* 39 Action10 <- <{ p.AddParamValue(text) }> */
+		/* 110 Action43 <- <{ p.AddParamHoleValue(text) }> */
 		nil,
-		/* 40 Action11 <- <{ p.AddParamIntValue(text) }> */
+		/* 111 Action44 <- <{ p.AddParamHoleType(text) }> */
 		nil,
-		/* 41 Action12 <- <{ p.AddParamValue(text) }> */
+		/* 112 Action45 <- <{ p.AddComment(text) }> */
 		nil,
-		/* 42 Action13 <- <{ p.LineDone() }> */
+		/* 113 Action46 <- <{ p.AddComment(text); p.LineDone() }> */
 		nil,
 	}
 	p.rules = _rules
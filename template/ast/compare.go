@@ -0,0 +1,312 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Equal compares structurally two ASTs: statements must match in order
+// while params, refs, aliases and holes are compared as sets
+func (a *AST) Equal(other *AST) bool {
+	if other == nil || len(a.Statements) != len(other.Statements) {
+		return false
+	}
+	for i, st := range a.Statements {
+		if !st.Node.equal(other.Statements[i].Node) || !reflect.DeepEqual(st.Guard, other.Statements[i].Guard) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualShape compares two ASTs as Equal does except that a hole matches
+// any literal or hole given for the same key (ex: "create vpc cidr={mycidr}"
+// has the shape of "create vpc cidr=10.0.0.0/16")
+func EqualShape(a, b *AST) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if len(a.Statements) != len(b.Statements) {
+		return false
+	}
+	for i, st := range a.Statements {
+		other := b.Statements[i]
+		if !reflect.DeepEqual(st.Guard, other.Guard) {
+			return false
+		}
+		n, o := st.Node, other.Node
+		if decl, ok := n.(*DeclarationNode); ok {
+			odecl, ok := o.(*DeclarationNode)
+			if !ok {
+				return false
+			}
+			n, o = shapeDeclarations(decl, odecl)
+		} else if expr, ok := n.(*ExpressionNode); ok {
+			if oexpr, ok := o.(*ExpressionNode); ok {
+				n, o = shapeExpressions(expr, oexpr)
+			}
+		}
+		if !n.equal(o) {
+			return false
+		}
+	}
+	return true
+}
+
+func shapeDeclarations(n, o *DeclarationNode) (*DeclarationNode, *DeclarationNode) {
+	right, oright := shapeExpressions(n.Right, o.Right)
+	return &DeclarationNode{Left: n.Left, Extra: n.Extra, Right: right},
+		&DeclarationNode{Left: o.Left, Extra: o.Extra, Right: oright}
+}
+
+// shapeExpressions returns copies of the expressions without the
+// keys having a hole on one side and a literal or a hole on the other
+func shapeExpressions(n, o *ExpressionNode) (*ExpressionNode, *ExpressionNode) {
+	isWildcard := func(e *ExpressionNode, k string) bool {
+		_, ok := e.Holes[k]
+		return ok
+	}
+	isLiteral := func(e *ExpressionNode, k string) bool {
+		_, ok := e.Params[k]
+		return ok && !e.Matches[k] && !e.hasUnresolvedItems(k)
+	}
+
+	var keys []string
+	for k := range n.Holes {
+		if (isLiteral(o, k) || isWildcard(o, k)) && !n.isItem(k) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range o.Holes {
+		if isLiteral(n, k) && !o.isItem(k) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return n, o
+	}
+
+	n, o = n.clone().(*ExpressionNode), o.clone().(*ExpressionNode)
+	for _, k := range keys {
+		for _, e := range []*ExpressionNode{n, o} {
+			delete(e.Params, k)
+			delete(e.Holes, k)
+			delete(e.HoleTypes, k)
+		}
+	}
+	return n, o
+}
+
+// Fingerprint returns a stable hash of the template content: templates
+// that are Equal share the same fingerprint whatever their formatting
+func (a *AST) Fingerprint() string {
+	h := sha256.New()
+	for _, st := range a.Statements {
+		if st.Guard != nil {
+			fmt.Fprintf(h, "when %q ", st.Guard)
+		}
+		fmt.Fprintln(h, canonical(st.Node))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// Canonical returns the template in a stable textual form meant for
+// machine comparison (ex: VCS diffs): one statement per line, single spaces,
+// and params of each statement grouped in the order literals, references,
+// aliases, holes, environment variables, each group sorted by key.
+// Equal templates have byte-identical canonical forms.
+func (a *AST) Canonical() string {
+	var lines []string
+	for _, st := range a.Statements {
+		var guard string
+		if st.Guard != nil {
+			guard = fmt.Sprintf("when %s ", st.Guard)
+		}
+		switch n := st.Node.(type) {
+		case *DeclarationNode:
+			lines = append(lines, fmt.Sprintf("%s%s = %s", guard, n.idents(), canonicalExpression(n.Right)))
+		case *ExpressionNode:
+			lines = append(lines, guard+canonicalExpression(n))
+		case *IncludeNode, *VarNode:
+			lines = append(lines, n.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func canonicalExpression(n *ExpressionNode) string {
+	all := []string{n.Action, n.Entity}
+	group := func(m map[string]string, format func(k, v string) string) {
+		var keys []string
+		for k := range m {
+			if !n.isItem(k) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			all = append(all, format(k, m[k]))
+		}
+	}
+
+	literals := make(map[string]string)
+	for k, v := range n.Params {
+		literals[k] = n.printValue(k, v)
+	}
+	group(literals, func(k, v string) string { return fmt.Sprintf("%s=%s", k, v) })
+	group(n.Refs, func(k, v string) string { return fmt.Sprintf("%s=%s", k, printRef(v)) })
+	group(n.Aliases, func(k, v string) string { return fmt.Sprintf("%s=%s", k, printAlias(v)) })
+	group(n.Holes, func(k, v string) string { return fmt.Sprintf("%s=%s", k, n.printHole(k)) })
+	group(n.Envs, func(k, v string) string { return fmt.Sprintf("%s=${ENV:%s}", k, v) })
+	flags := make(map[string]bool)
+	for _, flag := range n.Flags {
+		flags["--"+flag] = true
+	}
+	all = append(all, sortedKeys(flags)...)
+	all = append(all, sortedKeys(n.Modifiers)...)
+
+	return strings.Join(all, " ")
+}
+
+func canonical(n Node) string {
+	switch node := n.(type) {
+	case *DeclarationNode:
+		var idents []string
+		for _, ident := range node.Idents() {
+			idents = append(idents, fmt.Sprintf("%q", ident.Ident))
+		}
+		return fmt.Sprintf("%s = %s", strings.Join(idents, ", "), canonical(node.Right))
+	case *ExpressionNode:
+		var all []string
+		for k, v := range node.Params {
+			if node.Matches[k] {
+				all = append(all, fmt.Sprintf("%q=~%q", k, v))
+				continue
+			}
+			all = append(all, fmt.Sprintf("%q=%T:%#v", k, v, v))
+		}
+		for k, v := range node.Refs {
+			all = append(all, fmt.Sprintf("%q=$%q", k, v))
+		}
+		for k, v := range node.Aliases {
+			all = append(all, fmt.Sprintf("%q=@%q", k, v))
+		}
+		for k, v := range node.Holes {
+			all = append(all, fmt.Sprintf("%q={%q:%q}", k, v, node.HoleTypes[k]))
+		}
+		for k, v := range node.Envs {
+			all = append(all, fmt.Sprintf("%q=${ENV:%q}", k, v))
+		}
+		for k := range node.Modifiers {
+			all = append(all, fmt.Sprintf("%q", k))
+		}
+		for _, flag := range node.Flags {
+			all = append(all, fmt.Sprintf("--%q", flag))
+		}
+		sort.Strings(all)
+		return fmt.Sprintf("%q %q %s", node.Action, node.Entity, strings.Join(all, " "))
+	default:
+		return n.String()
+	}
+}
+
+func (n *IdentifierNode) equal(other Node) bool {
+	o, ok := other.(*IdentifierNode)
+	return ok && n.Ident == o.Ident
+}
+
+func (n *DeclarationNode) equal(other Node) bool {
+	o, ok := other.(*DeclarationNode)
+	if !ok || len(n.Extra) != len(o.Extra) {
+		return false
+	}
+	for i, ident := range n.Extra {
+		if !ident.equal(o.Extra[i]) {
+			return false
+		}
+	}
+	return n.Left.equal(o.Left) && n.Right.equal(o.Right)
+}
+
+func (n *ExpressionNode) equal(other Node) bool {
+	o, ok := other.(*ExpressionNode)
+	if !ok || n.Action != o.Action || n.Entity != o.Entity {
+		return false
+	}
+	if len(n.Params) != len(o.Params) {
+		return false
+	}
+	for k, v := range n.Params {
+		if ov, ok := o.Params[k]; !ok || !reflect.DeepEqual(v, ov) {
+			return false
+		}
+	}
+	return equalStringMaps(n.Refs, o.Refs) &&
+		equalStringMaps(n.Aliases, o.Aliases) &&
+		equalStringMaps(n.Holes, o.Holes) &&
+		equalStringMaps(n.HoleTypes, o.HoleTypes) &&
+		equalStringMaps(n.Envs, o.Envs) &&
+		equalBoolMaps(n.Matches, o.Matches) &&
+		equalBoolMaps(n.Modifiers, o.Modifiers) &&
+		equalFlags(n.Flags, o.Flags)
+}
+
+func equalStringMaps(m1, m2 map[string]string) bool {
+	if len(m1) != len(m2) {
+		return false
+	}
+	for k, v := range m1 {
+		if ov, ok := m2[k]; !ok || v != ov {
+			return false
+		}
+	}
+	return true
+}
+
+func equalBoolMaps(m1, m2 map[string]bool) bool {
+	if len(m1) != len(m2) {
+		return false
+	}
+	for k, v := range m1 {
+		if ov, ok := m2[k]; !ok || v != ov {
+			return false
+		}
+	}
+	return true
+}
+
+// equalFlags compares flags as sets, their order being irrelevant
+func equalFlags(f1, f2 []string) bool {
+	if len(f1) != len(f2) {
+		return false
+	}
+	set := make(map[string]bool)
+	for _, flag := range f1 {
+		set[flag] = true
+	}
+	for _, flag := range f2 {
+		if !set[flag] {
+			return false
+		}
+	}
+	return true
+}
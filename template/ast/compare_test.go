@@ -0,0 +1,155 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	tcases := []struct {
+		first, second string
+		equal         bool
+	}{
+		{
+			first:  "myvpc = create vpc cidr=10.0.0.0/16 name=any\ncreate subnet vpc=$myvpc zone={subnet.zone}",
+			second: "myvpc   =   create vpc name=any   cidr=10.0.0.0/16\n\n# comment\ncreate subnet zone={subnet.zone} vpc=$myvpc",
+			equal:  true,
+		},
+		{first: "create vpc", second: "create vpc", equal: true},
+		{first: "create vpc cidr=10.0.0.0/16", second: "create vpc cidr=10.0.0.0/24", equal: false},
+		{first: "create instance count=1", second: "create instance count=2", equal: false},
+		{first: "create instance count=1", second: "create instance count=1 name=any", equal: false},
+		{first: "create subnet vpc=$myvpc", second: "create subnet vpc=@myvpc", equal: false},
+		{first: "create subnet vpc={myvpc}", second: "create subnet vpc={myvpc:string}", equal: false},
+		{first: "create vpc", second: "delete vpc", equal: false},
+		{first: "create vpc", second: "myvpc = create vpc", equal: false},
+		{first: "myvpc = create vpc", second: "othervpc = create vpc", equal: false},
+		{first: "create vpc\ncreate subnet", second: "create subnet\ncreate vpc", equal: false},
+		{first: "create vpc\ncreate subnet", second: "create vpc", equal: false},
+	}
+
+	for _, tcase := range tcases {
+		first, second := mustParse(t, tcase.first), mustParse(t, tcase.second)
+		if got, want := first.Equal(second), tcase.equal; got != want {
+			t.Fatalf("%q equal %q: got %t, want %t", tcase.first, tcase.second, got, want)
+		}
+		if got, want := second.Equal(first), tcase.equal; got != want {
+			t.Fatalf("%q equal %q: got %t, want %t", tcase.second, tcase.first, got, want)
+		}
+	}
+}
+
+func TestEqualShape(t *testing.T) {
+	tcases := []struct {
+		first, second string
+		shape         bool
+	}{
+		{first: "create vpc cidr={mycidr}", second: "create vpc cidr=10.0.0.0/16", shape: true},
+		{first: "myvpc = create vpc cidr={mycidr:cidr} name=any", second: "myvpc = create vpc name=any cidr=10.0.0.0/16", shape: true},
+		{first: "create instance count={count}", second: "create instance count={instance.count}", shape: true},
+		{first: "create vpc cidr={mycidr}", second: "create vpc cidr=10.0.0.0/16 name=any", shape: false},
+		{first: "create vpc cidr={mycidr}", second: "create vpc", shape: false},
+		{first: "create subnet vpc={myvpc}", second: "create subnet vpc=$myvpc", shape: false},
+		{first: "create vpc cidr={mycidr}", second: "delete vpc cidr=10.0.0.0/16", shape: false},
+		{first: "create vpc cidr=10.0.0.0/16", second: "create vpc cidr=10.0.0.0/24", shape: false},
+		{first: "myvpc = create vpc cidr={mycidr}", second: "othervpc = create vpc cidr=10.0.0.0/16", shape: false},
+	}
+
+	for _, tcase := range tcases {
+		first, second := mustParse(t, tcase.first), mustParse(t, tcase.second)
+		if got, want := EqualShape(first, second), tcase.shape; got != want {
+			t.Fatalf("%q shape of %q: got %t, want %t", tcase.first, tcase.second, got, want)
+		}
+		if got, want := EqualShape(second, first), tcase.shape; got != want {
+			t.Fatalf("%q shape of %q: got %t, want %t", tcase.second, tcase.first, got, want)
+		}
+		if tcase.shape && first.Equal(second) {
+			t.Fatalf("%q equal %q: got true, want false", tcase.first, tcase.second)
+		}
+	}
+
+	tree := mustParse(t, "create vpc cidr={mycidr}")
+	EqualShape(tree, mustParse(t, "create vpc cidr=10.0.0.0/16"))
+	if got, want := tree.String(), "create vpc cidr={mycidr}"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	tcases := []struct {
+		first, second string
+		same          bool
+	}{
+		{
+			first:  "myvpc = create vpc cidr=10.0.0.0/16 name=any\ncreate subnet vpc=$myvpc zone={subnet.zone}",
+			second: "  myvpc=create vpc   name=any cidr=10.0.0.0/16\n// comment\n\ncreate subnet zone={ subnet.zone } vpc=$myvpc\n",
+			same:   true,
+		},
+		{first: "create vpc cidr=10.0.0.0/16", second: "create vpc cidr=10.0.0.0/24", same: false},
+		{first: "create instance count=1", second: "create instance count=2", same: false},
+		{first: "create subnet vpc=$myvpc", second: "create subnet vpc=@myvpc", same: false},
+		{first: "create vpc\ncreate subnet", second: "create subnet\ncreate vpc", same: false},
+		{first: "myvpc = create vpc", second: "othervpc = create vpc", same: false},
+	}
+
+	for _, tcase := range tcases {
+		first, second := mustParse(t, tcase.first), mustParse(t, tcase.second)
+		if got, want := first.Fingerprint() == second.Fingerprint(), tcase.same; got != want {
+			t.Fatalf("%q and %q: same fingerprint got %t, want %t", tcase.first, tcase.second, got, want)
+		}
+		if got, want := first.Equal(second), tcase.same; got != want {
+			t.Fatalf("%q and %q: equal got %t, want %t", tcase.first, tcase.second, got, want)
+		}
+	}
+
+	if got, want := len(mustParse(t, "create vpc").Fingerprint()), 64; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tcases := []struct {
+		input, golden string
+	}{
+		{input: "create vpc", golden: "create vpc"},
+		{
+			input:  "  myvpc   =  create   vpc name=any cidr=10.0.0.0/16  \n\n# comment\n",
+			golden: "myvpc = create vpc cidr=10.0.0.0/16 name=any",
+		},
+		{
+			input:  "create instance zone={instance.zone} subnet=$mysubnet name='my instance' count=2 group=@my-group type={instance.type:string} image=ami-123456 key=${ENV:KEY_NAME}",
+			golden: "create instance count=2 image=ami-123456 name='my instance' subnet=$mysubnet group=@my-group type={instance.type:string} zone={instance.zone} key=${ENV:KEY_NAME}",
+		},
+		{
+			input:  "myvpc = create vpc cidr=10.0.0.0/16;create subnet vpc=$myvpc cidr=10.0.1.0/24\n//comment\ncreate tags resource=$myvpc.id key=Name value=\"it's mine\"",
+			golden: "myvpc = create vpc cidr=10.0.0.0/16\ncreate subnet cidr=10.0.1.0/24 vpc=$myvpc\ncreate tags key=Name value=\"it's mine\" resource=$myvpc.id",
+		},
+	}
+
+	for _, tcase := range tcases {
+		tree := mustParse(t, tcase.input)
+		if got, want := tree.Canonical(), tcase.golden; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+		reparsed := mustParse(t, tree.Canonical())
+		if !reparsed.Equal(tree) {
+			t.Fatalf("%q: canonical form not equal to original", tcase.input)
+		}
+		if got, want := reparsed.Canonical(), tcase.golden; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// TopoSort returns the statements ordered so that each declaration comes
+// before the statements referencing it. Independent statements keep their
+// original relative order. It errors when references form a cycle.
+func (a *AST) TopoSort() ([]*Statement, error) {
+	deps := a.dependencies()
+
+	var sorted []*Statement
+	done := make([]bool, len(a.Statements))
+	for len(sorted) < len(a.Statements) {
+		progress := false
+		for i, st := range a.Statements {
+			if done[i] || !allDone(deps[i], done) {
+				continue
+			}
+			done[i], progress = true, true
+			sorted = append(sorted, st)
+			break
+		}
+		if !progress {
+			var cycle []string
+			for i, st := range a.Statements {
+				if !done[i] {
+					cycle = append(cycle, st.String())
+				}
+			}
+			return nil, fmt.Errorf("toposort: cycle in references between:\n%s", strings.Join(cycle, "\n"))
+		}
+	}
+
+	return sorted, nil
+}
+
+// Slice returns a new AST containing only the declaration of ident
+// and the declarations it transitively depends on, in dependency order
+func (a *AST) Slice(ident string) (*AST, error) {
+	deps := a.dependencies()
+
+	start := -1
+	for i, st := range a.Statements {
+		for _, declared := range st.declaredIdents() {
+			if declared == ident {
+				start = i
+			}
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("slice: no declaration '%s'", ident)
+	}
+
+	reached := make([]bool, len(a.Statements))
+	var walk func(i int)
+	walk = func(i int) {
+		if reached[i] {
+			return
+		}
+		reached[i] = true
+		for _, j := range deps[i] {
+			walk(j)
+		}
+	}
+	walk(start)
+
+	sub := &AST{}
+	for i, st := range a.Statements {
+		if reached[i] {
+			sub.Statements = append(sub.Statements, st.clone())
+		}
+	}
+
+	sorted, err := sub.TopoSort()
+	if err != nil {
+		return nil, err
+	}
+	sub.Statements = sorted
+
+	return sub, nil
+}
+
+// Dependents returns the statements depending on the declaration of ident,
+// directly or transitively, in source order. It is the inverse of Slice,
+// meant for impact analysis. There is none when ident is not declared.
+func (a *AST) Dependents(ident string) (dependents []*Statement) {
+	deps := a.dependencies()
+	referencing := make([][]int, len(a.Statements))
+	start := -1
+	for i, st := range a.Statements {
+		for _, j := range deps[i] {
+			referencing[j] = append(referencing[j], i)
+		}
+		for _, declared := range st.declaredIdents() {
+			if declared == ident {
+				start = i
+			}
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	reached := make([]bool, len(a.Statements))
+	var walk func(i int)
+	walk = func(i int) {
+		for _, j := range referencing[i] {
+			if !reached[j] {
+				reached[j] = true
+				walk(j)
+			}
+		}
+	}
+	walk(start)
+
+	for i, st := range a.Statements {
+		if reached[i] && i != start {
+			dependents = append(dependents, st)
+		}
+	}
+	return
+}
+
+// PruneUnused returns a new AST without the declarations and vars whose
+// result is never referenced, directly or through other pruned declarations.
+// Statements without assignment are kept as they are run for their side effects.
+func (a *AST) PruneUnused() *AST {
+	pruned := a.Clone()
+	for {
+		used := make(map[string]bool)
+		for _, expr := range pruned.expressionNodes() {
+			for _, ref := range expr.Refs {
+				used[ParseRefPath(ref).Name] = true
+			}
+			for _, hole := range expr.Holes {
+				used[hole] = true
+			}
+		}
+
+		var kept []*Statement
+		for _, st := range pruned.Statements {
+			if idents := st.declaredIdents(); len(idents) > 0 && !anyUsed(idents, used) {
+				continue
+			}
+			kept = append(kept, st)
+		}
+		if len(kept) == len(pruned.Statements) {
+			return pruned
+		}
+		pruned.Statements = kept
+	}
+}
+
+// InlineSingleUse returns a new AST where the declarations used exactly once
+// are inlined at their use site when directly substitutable. As expressions
+// cannot be nested, only refs to literal params of declarations above
+// (ex: $myvpc.params.cidr) are: the value replaces the ref and, its identifier
+// being unused, the declaration becomes a statement without assignment, still
+// run for its side effects. Anything ambiguous is left as is: refs to the result
+// of a declaration (ex: $myvpc), holes named after it, multiple assignments,
+// params that are not literals.
+func (a *AST) InlineSingleUse() *AST {
+	inlined := a.Clone()
+	uses := make(map[string]int)
+	for _, st := range inlined.Statements {
+		if st.Guard != nil && st.Guard.Hole != "" {
+			uses[st.Guard.Hole]++
+		}
+	}
+	for _, expr := range inlined.expressionNodes() {
+		for _, ref := range expr.Refs {
+			uses[ParseRefPath(ref).Name]++
+		}
+		for _, hole := range expr.Holes {
+			uses[hole]++
+		}
+	}
+
+	for i, st := range inlined.Statements {
+		decl, ok := st.Node.(*DeclarationNode)
+		if !ok || len(decl.Extra) > 0 || uses[decl.Left.Ident] != 1 {
+			continue
+		}
+		if inlined.inlineParamRef(i, decl) {
+			st.Node = decl.Right
+		}
+	}
+	return inlined
+}
+
+// inlineParamRef replaces the ref to a literal param of the declaration
+// found in the statements following it, reporting whether it did
+func (a *AST) inlineParamRef(index int, decl *DeclarationNode) bool {
+	for _, st := range a.Statements[index+1:] {
+		switch st.Node.(type) {
+		case *ExpressionNode, *DeclarationNode:
+		default:
+			continue
+		}
+		expr := st.expression()
+		for k, ref := range expr.Refs {
+			path := ParseRefPath(ref)
+			if path.Name != decl.Left.Ident {
+				continue
+			}
+			if !strings.HasPrefix(path.Attr, "params.") {
+				return false
+			}
+			param := strings.TrimPrefix(path.Attr, "params.")
+			val, ok := decl.Right.Params[param]
+			if _, interpolated := val.(Interpolation); !ok || interpolated || decl.Right.Matches[param] || decl.Right.hasUnresolvedItems(param) {
+				return false
+			}
+			expr.Params[k] = cloneValue(val)
+			delete(expr.Refs, k)
+			delete(expr.Raw, k)
+			expr.mergeItems()
+			return true
+		}
+	}
+	return false
+}
+
+// hasUnresolvedItems reports whether items of the list param of the key
+// are refs, aliases or holes
+func (n *ExpressionNode) hasUnresolvedItems(key string) bool {
+	for _, m := range []map[string]string{n.Refs, n.Aliases, n.Holes} {
+		for k := range m {
+			if strings.HasPrefix(k, key+"[") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ToDOT returns the dependency graph of the template in Graphviz DOT format.
+// Declarations are nodes named after their identifiers, statements without
+// assignment are leaf nodes named after their position, and edges go from
+// a declaration to the statements referencing it.
+func (a *AST) ToDOT() string {
+	ids := make([]string, len(a.Statements))
+	var buff bytes.Buffer
+	buff.WriteString("digraph template {\n")
+	for i, st := range a.Statements {
+		expr := st.expression()
+		label := fmt.Sprintf("%s %s", expr.Action, expr.Entity)
+		switch n := st.Node.(type) {
+		case *DeclarationNode:
+			ids[i] = n.idents()
+			label = ids[i] + "\n" + label
+		case *VarNode:
+			ids[i], label = quoteName(n.Ident), n.String()
+		case *IncludeNode:
+			ids[i], label = fmt.Sprintf("statement%d", i+1), "include "+n.Path
+		default:
+			ids[i] = fmt.Sprintf("statement%d", i+1)
+		}
+		fmt.Fprintf(&buff, "\t%q [label=%q];\n", ids[i], label)
+	}
+	for i, deps := range a.dependencies() {
+		drawn := make(map[int]bool)
+		for _, j := range deps {
+			if !drawn[j] {
+				drawn[j] = true
+				fmt.Fprintf(&buff, "\t%q -> %q;\n", ids[j], ids[i])
+			}
+		}
+	}
+	buff.WriteString("}\n")
+	return buff.String()
+}
+
+func anyUsed(idents []string, used map[string]bool) bool {
+	for _, ident := range idents {
+		if used[ident] {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencies returns for each statement (by index) the indexes of the
+// declarations it references. References to undeclared names are ignored.
+func (a *AST) dependencies() [][]int {
+	declared := make(map[string]int)
+	for i, st := range a.Statements {
+		for _, ident := range st.declaredIdents() {
+			declared[ident] = i
+		}
+	}
+
+	deps := make([][]int, len(a.Statements))
+	for i, st := range a.Statements {
+		for _, name := range st.refNames() {
+			if j, ok := declared[name]; ok {
+				deps[i] = append(deps[i], j)
+			}
+		}
+	}
+	return deps
+}
+
+func (s *Statement) refNames() (names []string) {
+	var expr *ExpressionNode
+	switch n := s.Node.(type) {
+	case *ExpressionNode:
+		expr = n
+	case *DeclarationNode:
+		expr = n.Right
+	}
+	if expr == nil {
+		return
+	}
+	uniq := make(map[string]bool)
+	for _, ref := range expr.Refs {
+		uniq[ParseRefPath(ref).Name] = true
+	}
+	return sortedKeys(uniq)
+}
+
+func allDone(indexes []int, done []bool) bool {
+	for _, i := range indexes {
+		if !done[i] {
+			return false
+		}
+	}
+	return true
+}
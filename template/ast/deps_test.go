@@ -0,0 +1,273 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "testing"
+
+func TestTopoSort(t *testing.T) {
+	t.Run("out of order statements", func(t *testing.T) {
+		tree := mustParse(t, "create instance subnet=$mysubnet\nmysubnet = create subnet vpc=$myvpc\ncreate tags resource=$myvpc.id\nmyvpc = create vpc cidr=10.0.0.0/16")
+
+		sorted, err := tree.TopoSort()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, st := range sorted {
+			got = append(got, st.Entity())
+		}
+		expected := []string{"vpc", "subnet", "instance", "tags"}
+		if len(got) != len(expected) {
+			t.Fatalf("got %v, want %v", got, expected)
+		}
+		for i := range expected {
+			if got[i] != expected[i] {
+				t.Fatalf("got %v, want %v", got, expected)
+			}
+		}
+	})
+
+	t.Run("vars", func(t *testing.T) {
+		tree := mustParse(t, "create vpc cidr=$cidr\nvar cidr=10.0.0.0/16")
+
+		sorted, err := tree.TopoSort()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := sorted[0], tree.Statements[1]; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		tree := mustParse(t, "myvpc = create vpc name=$mysubnet\nmysubnet = create subnet vpc=$myvpc\ncreate instance")
+		if _, err := tree.TopoSort(); err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+}
+
+func TestSlice(t *testing.T) {
+	tree := mustParse(t, `myinstance = create instance subnet=$mysubnet
+mysubnet = create subnet vpc=$myvpc
+myvpc = create vpc cidr=10.0.0.0/16
+othersubnet = create subnet vpc=$myvpc
+mygroup = create securitygroup vpc=$myvpc
+create tags resource=$myinstance`)
+
+	sub, err := tree.Slice("myinstance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, st := range sub.Statements {
+		got = append(got, st.Node.(*DeclarationNode).Left.Ident)
+	}
+	expected := []string{"myvpc", "mysubnet", "myinstance"}
+	if len(got) != len(expected) {
+		t.Fatalf("got %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("got %v, want %v", got, expected)
+		}
+	}
+
+	sub.Statements[0].Node.(*DeclarationNode).Right.Params["new"] = "value"
+	if _, ok := tree.Statements[2].Node.(*DeclarationNode).Right.Params["new"]; ok {
+		t.Fatal("expected slice not to share statements with original")
+	}
+
+	if _, err := tree.Slice("unknown"); err == nil {
+		t.Fatal("expected error got none")
+	}
+
+	tree = mustParse(t, "var other=1\nmyvpc = create vpc cidr=$cidr\nvar cidr=10.0.0.0/16")
+	sub, err = tree.Slice("myvpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sub.String(), "var cidr=10.0.0.0/16\nmyvpc = create vpc cidr=$cidr"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	sub, err = tree.Slice("cidr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sub.String(), "var cidr=10.0.0.0/16"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestDependents(t *testing.T) {
+	tree := mustParse(t, `myvpc = create vpc cidr=10.0.0.0/16
+left = create subnet vpc=$myvpc
+right = create securitygroup vpc=$myvpc
+myinstance = create instance subnet=$left securitygroup=$right
+create tags resource=$myinstance
+other = create vpc cidr=10.1.0.0/16
+create subnet vpc=$other`)
+
+	dependents := tree.Dependents("myvpc")
+	if got, want := len(dependents), 4; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for i, index := range []int{1, 2, 3, 4} {
+		if dependents[i] != tree.Statements[index] {
+			t.Fatalf("dependent %d: got %s, want %s", i, dependents[i], tree.Statements[index])
+		}
+	}
+
+	if got, want := len(tree.Dependents("right")), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got := tree.Dependents("myinstance"); len(got) != 1 || got[0] != tree.Statements[4] {
+		t.Fatalf("got %v, want the tags statement", got)
+	}
+	if got := tree.Dependents("unknown"); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+
+	tree = mustParse(t, "var cidr=10.0.0.0/16\nmyvpc = create vpc cidr=$cidr\ncreate subnet vpc=$myvpc")
+	if got, want := len(tree.Dependents("cidr")), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestToDOT(t *testing.T) {
+	tree := mustParse(t, `myvpc = create vpc cidr=10.0.0.0/16
+mysubnet = create subnet vpc=$myvpc cidr=10.0.0.0/24
+create instance subnet=$mysubnet vpc=$myvpc count=2 name=$mysubnet
+mykey, mysecret = create accesskey
+create tags key=$mysecret`)
+
+	expected := `digraph template {
+	"myvpc" [label="myvpc\ncreate vpc"];
+	"mysubnet" [label="mysubnet\ncreate subnet"];
+	"statement3" [label="create instance"];
+	"mykey, mysecret" [label="mykey, mysecret\ncreate accesskey"];
+	"statement5" [label="create tags"];
+	"myvpc" -> "mysubnet";
+	"mysubnet" -> "statement3";
+	"myvpc" -> "statement3";
+	"mykey, mysecret" -> "statement5";
+}
+`
+	if got, want := tree.ToDOT(), expected; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+
+	tree = mustParse(t, `"my \"main\" vpc" = create vpc cidr=10.0.0.0/16
+create subnet vpc=$"my \"main\" vpc"`)
+
+	expected = `digraph template {
+	"\"my \\\"main\\\" vpc\"" [label="\"my \\\"main\\\" vpc\"\ncreate vpc"];
+	"statement2" [label="create subnet"];
+	"\"my \\\"main\\\" vpc\"" -> "statement2";
+}
+`
+	if got, want := tree.ToDOT(), expected; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	tree = mustParse(t, "var net=10.0.0.0/24\ncreate subnet cidr=$net")
+
+	expected = `digraph template {
+	"net" [label="var net=10.0.0.0/24"];
+	"statement2" [label="create subnet"];
+	"net" -> "statement2";
+}
+`
+	if got, want := tree.ToDOT(), expected; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPruneUnused(t *testing.T) {
+	tree := mustParse(t, `myvpc = create vpc cidr=10.0.0.0/16
+tmpsubnet = create subnet vpc=$myvpc
+tmp = create securitygroup vpc=$tmpsubnet
+mysubnet = create subnet vpc=$myvpc
+create instance subnet=$mysubnet
+delete instance id=i-54g3hj`)
+
+	pruned := tree.PruneUnused()
+
+	var got []string
+	for _, st := range pruned.Statements {
+		got = append(got, st.Action()+" "+st.Entity())
+	}
+	expected := []string{"create vpc", "create subnet", "create instance", "delete instance"}
+	if len(got) != len(expected) {
+		t.Fatalf("got %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("got %v, want %v", got, expected)
+		}
+	}
+	if got, want := pruned.Statements[1].Node.(*DeclarationNode).Left.Ident, "mysubnet"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := len(tree.Statements), 6; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	tree = mustParse(t, "var unused=1\nvar cidr=10.0.0.0/16\ncreate vpc cidr=$cidr")
+	if got, want := tree.PruneUnused().String(), "var cidr=10.0.0.0/16\ncreate vpc cidr=$cidr"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func mustParse(t *testing.T, text string) *AST {
+	tree, err := Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestInlineSingleUse(t *testing.T) {
+	tree := mustParse(t, `myvpc = create vpc cidr=10.0.0.0/16
+create subnet cidr=$myvpc.params.cidr
+shared = create vpc cidr=10.1.0.0/16
+create subnet cidr=$shared.params.cidr
+create subnet cidr=$shared.params.cidr
+mysubnet = create subnet cidr=10.2.0.0/24
+create instance subnet=$mysubnet
+mylist = create group zones=[eu,{zone}]
+create instance zones=$mylist.params.zones`)
+
+	inlined := tree.InlineSingleUse()
+	expected := `create vpc cidr=10.0.0.0/16
+create subnet cidr=10.0.0.0/16
+shared = create vpc cidr=10.1.0.0/16
+create subnet cidr=$shared.params.cidr
+create subnet cidr=$shared.params.cidr
+mysubnet = create subnet cidr=10.2.0.0/24
+create instance subnet=$mysubnet
+mylist = create group zones=[eu,{zone}]
+create instance zones=$mylist.params.zones`
+	if got, want := inlined.String(), expected; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if got, want := len(inlined.Statements), len(tree.Statements); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if _, ok := tree.Statements[0].Node.(*DeclarationNode); !ok {
+		t.Fatal("expected original template untouched")
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Aligned returns the template formatted for human editing: each param of
+// a statement on its own continued line, sorted by key, with the '=' of
+// the params aligned in a column. For instance:
+//
+//	mysubnet = create subnet \
+//		cidr = 10.0.0.0/24 \
+//		vpc  = $myvpc
+func (a *AST) Aligned() string {
+	var all []string
+	for _, st := range a.Statements {
+		var head string
+		switch n := st.Node.(type) {
+		case *DeclarationNode:
+			head = fmt.Sprintf("%s = %s %s", n.idents(), n.Right.Action, n.Right.Entity)
+		case *ExpressionNode:
+			head = fmt.Sprintf("%s %s", n.Action, n.Entity)
+		default:
+			all = append(all, n.String())
+			continue
+		}
+		if st.Guard != nil {
+			head = fmt.Sprintf("when %s %s", st.Guard, head)
+		}
+
+		params := st.SortedParams()
+		var width int
+		for _, p := range params {
+			if len(p.Key) > width {
+				width = len(p.Key)
+			}
+		}
+		lines := []string{head}
+		for _, p := range params {
+			lines = append(lines, fmt.Sprintf("\t%-*s = %s", width, p.Key, p.Value))
+		}
+		all = append(all, strings.Join(lines, " \\\n"))
+	}
+	return a.joinLines(all)
+}
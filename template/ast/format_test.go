@@ -0,0 +1,42 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "testing"
+
+func TestAligned(t *testing.T) {
+	tree := mustParse(t, `myvpc = create vpc
+mysubnet = create subnet vpc=$myvpc cidr=10.0.0.0/24
+when {large} create instance subnet=$mysubnet count=2 name='my instance' instance.type={instance.type} group=@web`)
+
+	expected := `myvpc = create vpc
+mysubnet = create subnet \
+	cidr = 10.0.0.0/24 \
+	vpc  = $myvpc
+when {large} create instance \
+	count         = 2 \
+	group         = @web \
+	instance.type = {instance.type} \
+	name          = 'my instance' \
+	subnet        = $mysubnet`
+	if got, want := tree.Aligned(), expected; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if reparsed := mustParse(t, tree.Aligned()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GoString methods give compact and readable dumps of the nodes
+// when debugging with %#v (ex: t.Logf("%#v", stmt))
+
+func (s *Statement) GoString() string {
+	if s.Err != nil {
+		return fmt.Sprintf("&ast.Statement{Line: %d, Node: %#v, Err: %q}", s.LineNumber, s.Node, s.Err.Error())
+	}
+	return fmt.Sprintf("&ast.Statement{Line: %d, Node: %#v}", s.LineNumber, s.Node)
+}
+
+func (n *DeclarationNode) GoString() string {
+	if len(n.Extra) > 0 {
+		return fmt.Sprintf("&ast.DeclarationNode{Left: %#v, Extra: %#v, Right: %#v}", n.Left, n.Extra, n.Right)
+	}
+	return fmt.Sprintf("&ast.DeclarationNode{Left: %#v, Right: %#v}", n.Left, n.Right)
+}
+
+func (n *VarNode) GoString() string {
+	return fmt.Sprintf("&ast.VarNode{Ident: %q, Value: %#v}", n.Ident, n.Value)
+}
+
+func (n *IdentifierNode) GoString() string {
+	return fmt.Sprintf("&ast.IdentifierNode{Ident: %q}", n.Ident)
+}
+
+func (n *ExpressionNode) GoString() string {
+	var params []string
+	for _, p := range n.sortedParams() {
+		params = append(params, fmt.Sprintf("%s=%s", p.Key, p.Value))
+	}
+	return fmt.Sprintf("&ast.ExpressionNode{Action: %q, Entity: %q, Params: [%s]}", n.Action, n.Entity, strings.Join(params, " "))
+}
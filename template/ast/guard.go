@@ -0,0 +1,181 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Guard conditions the run of a statement (ex: when {large} create instance):
+// either a hole to be filled with a boolean or a boolean literal, optionally
+// negated (ex: when !{dryrun}), or a hole compared with a literal operand
+// (ex: when {count} > 0)
+type Guard struct {
+	Hole  string
+	Value bool
+	Not   bool
+	// Op is one of ==, !=, <, <=, >, >= comparing the hole with Operand,
+	// a string, a bool, an int or a float64
+	Op      string
+	Operand interface{}
+}
+
+func (g *Guard) String() string {
+	var cond string
+	if g.Hole != "" {
+		cond = fmt.Sprintf("{%s}", g.Hole)
+	} else {
+		cond = strconv.FormatBool(g.Value)
+	}
+	if g.Op != "" {
+		cond = fmt.Sprintf("%s %s %s", cond, g.Op, printGuardOperand(g.Operand))
+	}
+	if g.Not {
+		return "!" + cond
+	}
+	return cond
+}
+
+// Eval evaluates the guard with the fills of the holes. Filled values may
+// be typed or strings (ex: "true", "3") as when given on the command line.
+func (g *Guard) Eval(fills map[string]interface{}) (bool, error) {
+	var val interface{} = g.Value
+	if g.Hole != "" {
+		var ok bool
+		if val, ok = fills[g.Hole]; !ok {
+			return false, fmt.Errorf("guard %s: hole '%s' not filled", g, g.Hole)
+		}
+	}
+
+	var result bool
+	if g.Op == "" {
+		b, ok := toBool(val)
+		if !ok {
+			return false, fmt.Errorf("guard %s: '%v' is not a boolean", g, val)
+		}
+		result = b
+	} else {
+		cmp, ok := compareGuardValues(val, g.Operand)
+		if !ok || (cmp.unordered && g.Op != "==" && g.Op != "!=") {
+			return false, fmt.Errorf("guard %s: cannot compare '%v' with '%v'", g, val, g.Operand)
+		}
+		switch g.Op {
+		case "==":
+			result = cmp.sign == 0
+		case "!=":
+			result = cmp.sign != 0
+		case "<":
+			result = cmp.sign < 0
+		case "<=":
+			result = cmp.sign <= 0
+		case ">":
+			result = cmp.sign > 0
+		case ">=":
+			result = cmp.sign >= 0
+		default:
+			return false, fmt.Errorf("guard %s: unknown operator '%s'", g, g.Op)
+		}
+	}
+
+	return result != g.Not, nil
+}
+
+type comparison struct {
+	sign      int
+	unordered bool
+}
+
+// compareGuardValues compares a filled value with an operand: as numbers
+// when the operand is a number, as booleans or as strings otherwise
+func compareGuardValues(val, operand interface{}) (comparison, bool) {
+	switch op := operand.(type) {
+	case int, float64:
+		a, ok := toFloat(val)
+		if !ok {
+			return comparison{}, false
+		}
+		b, _ := toFloat(op)
+		switch {
+		case a < b:
+			return comparison{sign: -1}, true
+		case a > b:
+			return comparison{sign: 1}, true
+		}
+		return comparison{}, true
+	case bool:
+		b, ok := toBool(val)
+		if !ok {
+			return comparison{}, false
+		}
+		if b != op {
+			return comparison{sign: 1, unordered: true}, true
+		}
+		return comparison{unordered: true}, true
+	case string:
+		return comparison{sign: strings.Compare(fmt.Sprint(val), op)}, true
+	}
+	return comparison{}, false
+}
+
+func toBool(v interface{}) (bool, bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, true
+	case string:
+		parsed, err := strconv.ParseBool(b)
+		return parsed, err == nil
+	}
+	return false, false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func parseGuardOperand(text string) interface{} {
+	switch {
+	case strings.HasPrefix(text, "'"):
+		return strings.Trim(text, "'")
+	case text == "true" || text == "false":
+		return text == "true"
+	case strings.Contains(text, "."):
+		f, _ := strconv.ParseFloat(text, 64)
+		return f
+	}
+	n, _ := strconv.Atoi(text)
+	return n
+}
+
+func printGuardOperand(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return "'" + s + "'"
+	}
+	return fmt.Sprint(v)
+}
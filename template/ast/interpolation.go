@@ -0,0 +1,166 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Interpolation is a double quoted string value embedding holes
+// (ex: "web-{env}-01"), rendered as a string once its holes are filled.
+// Single quoted strings and escaped braces (ex: "\{not-a-hole\}")
+// are never interpolated.
+type Interpolation []InterpolationPart
+
+// InterpolationPart is either a literal text or the placeholder of a hole
+type InterpolationPart struct {
+	Text, Hole string
+}
+
+func (in Interpolation) String() string {
+	var buff bytes.Buffer
+	for _, part := range in {
+		if part.Hole != "" {
+			buff.WriteString("{" + part.Hole + "}")
+		} else {
+			buff.WriteString(part.Text)
+		}
+	}
+	return buff.String()
+}
+
+// quoted prints the interpolation as a double quoted string,
+// escaping the braces of its text
+func (in Interpolation) quoted() string {
+	var buff bytes.Buffer
+	buff.WriteByte('"')
+	for _, part := range in {
+		if part.Hole != "" {
+			buff.WriteString("{" + part.Hole + "}")
+		} else {
+			quoted := quoteEscapingBraces(part.Text)
+			buff.WriteString(quoted[1 : len(quoted)-1])
+		}
+	}
+	buff.WriteByte('"')
+	return buff.String()
+}
+
+var braceEscaper = strings.NewReplacer("{", `\{`, "}", `\}`)
+
+// quoteEscapingBraces double quotes a string so that it is parsed
+// back as is, without interpolation
+func quoteEscapingBraces(str string) string {
+	return braceEscaper.Replace(strconv.Quote(str))
+}
+
+func (in Interpolation) appendText(text string) Interpolation {
+	if text == "" {
+		return in
+	}
+	if last := len(in) - 1; last >= 0 && in[last].Hole == "" {
+		in[last].Text += text
+		return in
+	}
+	return append(in, InterpolationPart{Text: text})
+}
+
+func (in Interpolation) filled() bool {
+	for _, part := range in {
+		if part.Hole != "" {
+			return false
+		}
+	}
+	return true
+}
+
+var interpolatedHole = regexp.MustCompile(`\{\s*(` + plainNamePattern + `)\s*\}`)
+
+// parseInterpolation splits a string around the holes it embeds,
+// reporting false when there is none
+func parseInterpolation(str string) (Interpolation, bool) {
+	locs := interpolatedHole.FindAllStringSubmatchIndex(str, -1)
+	if locs == nil {
+		return nil, false
+	}
+	var in Interpolation
+	var last int
+	for _, loc := range locs {
+		if loc[0] > last {
+			in = append(in, InterpolationPart{Text: str[last:loc[0]]})
+		}
+		in = append(in, InterpolationPart{Hole: str[loc[2]:loc[3]]})
+		last = loc[1]
+	}
+	if last < len(str) {
+		in = append(in, InterpolationPart{Text: str[last:]})
+	}
+	return in, true
+}
+
+// parseQuoted unquotes the content of a double quoted string value
+// and splits it around the holes it embeds, if any. Escaped braces
+// are literal braces, unescaped before looking for holes.
+func parseQuoted(text string) (str string, in Interpolation, err error) {
+	var buff bytes.Buffer
+	var interpolated bool
+	add := func(segment, brace string) error {
+		unquoted, err := strconv.Unquote(`"` + segment + `"`)
+		if err != nil {
+			return err
+		}
+		buff.WriteString(unquoted + brace)
+		if parts, ok := parseInterpolation(unquoted); ok {
+			interpolated = true
+			for _, part := range parts {
+				if part.Hole != "" {
+					in = append(in, part)
+				} else {
+					in = in.appendText(part.Text)
+				}
+			}
+		} else {
+			in = in.appendText(unquoted)
+		}
+		in = in.appendText(brace)
+		return nil
+	}
+
+	var start int
+	for i := 0; i < len(text)-1; i++ {
+		if text[i] != '\\' {
+			continue
+		}
+		if c := text[i+1]; c == '{' || c == '}' {
+			if err = add(text[start:i], string(c)); err != nil {
+				return
+			}
+			start = i + 2
+		}
+		i++
+	}
+	if err = add(text[start:], ""); err != nil {
+		return
+	}
+	if !interpolated {
+		in = nil
+	}
+	return buff.String(), in, nil
+}
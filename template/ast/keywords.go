@@ -0,0 +1,91 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+// Action is a known action keyword of statements (ex: create)
+type Action string
+
+// Entity is a known entity keyword of statements (ex: vpc)
+type Entity string
+
+const (
+	ActionAttach Action = "attach"
+	ActionCheck  Action = "check"
+	ActionCreate Action = "create"
+	ActionDelete Action = "delete"
+	ActionDetach Action = "detach"
+	ActionEnsure Action = "ensure"
+	ActionStart  Action = "start"
+	ActionStop   Action = "stop"
+	ActionUpdate Action = "update"
+)
+
+const (
+	EntityBucket          Entity = "bucket"
+	EntityGroup           Entity = "group"
+	EntityInstance        Entity = "instance"
+	EntityInternetGateway Entity = "internetgateway"
+	EntityKeyPair         Entity = "keypair"
+	EntityPolicy          Entity = "policy"
+	EntityRoute           Entity = "route"
+	EntityRouteTable      Entity = "routetable"
+	EntitySecurityGroup   Entity = "securitygroup"
+	EntityStorageObject   Entity = "storageobject"
+	EntitySubnet          Entity = "subnet"
+	EntityTags            Entity = "tags"
+	EntityUser            Entity = "user"
+	EntityVolume          Entity = "volume"
+	EntityVPC             Entity = "vpc"
+)
+
+var (
+	actions  = []Action{ActionAttach, ActionCheck, ActionCreate, ActionDelete, ActionDetach, ActionEnsure, ActionStart, ActionStop, ActionUpdate}
+	entities = []Entity{EntityBucket, EntityGroup, EntityInstance, EntityInternetGateway, EntityKeyPair, EntityPolicy, EntityRoute, EntityRouteTable, EntitySecurityGroup, EntityStorageObject, EntitySubnet, EntityTags, EntityUser, EntityVolume, EntityVPC}
+)
+
+func (a Action) String() string { return string(a) }
+func (e Entity) String() string { return string(e) }
+
+// ParseAction returns the action of the keyword, reporting whether it is known
+func ParseAction(s string) (Action, bool) {
+	for _, a := range actions {
+		if string(a) == s {
+			return a, true
+		}
+	}
+	return Action(s), false
+}
+
+// ParseEntity returns the entity of the keyword, reporting whether it is known
+func ParseEntity(s string) (Entity, bool) {
+	for _, e := range entities {
+		if string(e) == s {
+			return e, true
+		}
+	}
+	return Entity(s), false
+}
+
+// ActionType returns the action of the expression as an Action
+func (n *ExpressionNode) ActionType() Action {
+	return Action(n.Action)
+}
+
+// EntityType returns the entity of the expression as an Entity
+func (n *ExpressionNode) EntityType() Entity {
+	return Entity(n.Entity)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "testing"
+
+func TestParseKeywords(t *testing.T) {
+	for _, want := range actions {
+		if got, ok := ParseAction(want.String()); !ok || got != want {
+			t.Fatalf("got %s (%t), want %s", got, ok, want)
+		}
+	}
+	for _, want := range entities {
+		if got, ok := ParseEntity(want.String()); !ok || got != want {
+			t.Fatalf("got %s (%t), want %s", got, ok, want)
+		}
+	}
+	if got, want := ParseAction("create"); got != ActionCreate || !want {
+		t.Fatalf("got %s, want %s", got, ActionCreate)
+	}
+	for _, unknown := range []string{"reboot", "Create", ""} {
+		if _, ok := ParseAction(unknown); ok {
+			t.Fatalf("%q: expected unknown action", unknown)
+		}
+	}
+	for _, unknown := range []string{"loadbalancer", "VPC", ""} {
+		if _, ok := ParseEntity(unknown); ok {
+			t.Fatalf("%q: expected unknown entity", unknown)
+		}
+	}
+
+	expr := mustParse(t, "create vpc").Statements[0].Node.(*ExpressionNode)
+	if expr.ActionType() != ActionCreate || expr.EntityType() != EntityVPC {
+		t.Fatalf("got %s %s, want %s %s", expr.ActionType(), expr.EntityType(), ActionCreate, EntityVPC)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "errors"
+
+var (
+	ErrMaxBytes      = errors.New("template exceeds maximum size")
+	ErrMaxStatements = errors.New("template exceeds maximum number of statements")
+	ErrMaxParams     = errors.New("statement exceeds maximum number of params")
+)
+
+// Limits bounds the templates accepted by ParseWithLimits.
+// A zero value means no limit.
+type Limits struct {
+	MaxBytes              int
+	MaxStatements         int
+	MaxParamsPerStatement int
+}
+
+// limitExceeded is raised by the AST builder, while executing the parse,
+// as soon as a limit is exceeded
+type limitExceeded struct {
+	err error
+}
+
+// ParseWithLimits parses src aborting with ErrMaxBytes, ErrMaxStatements
+// or ErrMaxParams when the corresponding limit is exceeded
+func ParseWithLimits(src string, opts Limits) (tree *AST, err error) {
+	if opts.MaxBytes > 0 && len(src) > opts.MaxBytes {
+		return nil, ErrMaxBytes
+	}
+
+	p := &Peg{AST: &AST{limits: &opts}, Buffer: src, Pretty: true}
+	p.Init()
+
+	if err := p.ParseAll(); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			exceeded, ok := r.(limitExceeded)
+			if !ok {
+				panic(r)
+			}
+			tree, err = nil, exceeded.err
+		}
+	}()
+	if err := p.execute(); err != nil {
+		return nil, err
+	}
+
+	p.AST.limits = nil
+	return p.AST, nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "testing"
+
+func TestParseWithLimits(t *testing.T) {
+	src := "myvpc = create vpc cidr=10.0.0.0/16 name=any\ncreate subnet vpc=$myvpc cidr=10.0.1.0/24 name=other\ncreate instance"
+	limits := Limits{MaxBytes: len(src), MaxStatements: 3, MaxParamsPerStatement: 3}
+
+	tree, err := ParseWithLimits(src, limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(tree.Statements), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	tcases := []struct {
+		limits Limits
+		err    error
+	}{
+		{limits: Limits{MaxBytes: len(src) - 1}, err: ErrMaxBytes},
+		{limits: Limits{MaxStatements: 2}, err: ErrMaxStatements},
+		{limits: Limits{MaxParamsPerStatement: 2}, err: ErrMaxParams},
+	}
+	for _, tcase := range tcases {
+		if _, err := ParseWithLimits(src, tcase.limits); err != tcase.err {
+			t.Fatalf("%+v: got %v, want %v", tcase.limits, err, tcase.err)
+		}
+	}
+
+	if _, err := ParseWithLimits("create vpc cidr=", limits); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// keywordsRegex matches the start of a statement: an optional guard,
+// an optional declaration identifier, then the action and entity keywords
+var keywordsRegex = regexp.MustCompile(`^[\s;]*(?:((?i:when))\s+\S+(?:\s*(?:[<>!=]=|[<>])\s*\S+)?\s+)?(?:[a-zA-Z0-9-_.]+\s*=\s*)?([a-zA-Z]+)\s+([a-zA-Z-_.]+)(?:\s|$)`)
+
+// NormalizeKeywords lowercases the action and entity keywords of each
+// statement (ex: "Create VPC" into "create vpc") before parsing.
+// Declaration identifiers, param keys, values and comments are left untouched.
+func NormalizeKeywords(src string) string {
+	var buff bytes.Buffer
+	start := 0
+	flush := func(end int) {
+		if end > len(src) {
+			end = len(src)
+		}
+		buff.WriteString(normalizeStatement(src[start:end]))
+		start = end
+	}
+
+	for i := 0; i < len(src); i++ {
+		switch c := src[i]; {
+		case c == '\'':
+			i = skipUntil(src, i+1, "'")
+		case c == '"':
+			for i++; i < len(src) && src[i] != '"'; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+			}
+		case strings.HasPrefix(src[i:], "<<EOF"):
+			i = skipUntil(src, i, "\nEOF")
+		case strings.HasPrefix(src[i:], "/*"):
+			i = skipUntil(src, i+2, "*/")
+			flush(i + 1)
+		case (c == '#' || strings.HasPrefix(src[i:], "//")) && (i == 0 || strings.ContainsRune(" \t\n;", rune(src[i-1]))):
+			i = skipUntil(src, i, "\n") - 1
+		case c == '\\' && strings.HasPrefix(src[i+1:], "\n"):
+			i++
+		case c == '\n' || c == ';':
+			flush(i)
+		}
+	}
+	flush(len(src))
+
+	return buff.String()
+}
+
+func normalizeStatement(st string) string {
+	loc := keywordsRegex.FindStringSubmatchIndex(st)
+	if loc == nil {
+		return st
+	}
+	b := []byte(st)
+	for g := 1; g <= 3; g++ {
+		if begin, end := loc[2*g], loc[2*g+1]; begin >= 0 {
+			copy(b[begin:end], strings.ToLower(st[begin:end]))
+		}
+	}
+	return string(b)
+}
+
+// skipUntil returns the index of the last byte of the first
+// occurrence of sep from i, or the end of src when not found
+func skipUntil(src string, i int, sep string) int {
+	if i > len(src) {
+		return len(src)
+	}
+	if index := strings.Index(src[i:], sep); index >= 0 {
+		return i + index + len(sep) - 1
+	}
+	return len(src)
+}
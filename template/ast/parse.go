@@ -0,0 +1,443 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The parser is generated by a peg patched with peg.patch
+//go:generate peg -switch -inline awless-template-syntax.peg
+
+// ParseReader parses the template read from r
+func ParseReader(r io.Reader) (*AST, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(content))
+}
+
+// ParseFile parses the template file at path
+func ParseFile(path string) (*AST, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseReader(f)
+}
+
+// ParseContext parses src while checking regularly for ctx cancellation,
+// in which case the parse is aborted and ctx error returned
+func ParseContext(ctx context.Context, src string) (*AST, error) {
+	p := &Peg{AST: &AST{}, Buffer: src, Pretty: true, done: ctx.Done()}
+	p.Init()
+
+	if err := p.ParseAll(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+	if err := p.execute(); err != nil {
+		return nil, err
+	}
+
+	return p.AST, nil
+}
+
+// Parse parses a template
+func Parse(text string) (*AST, error) {
+	p := &Peg{AST: &AST{}, Buffer: text, Pretty: true}
+	p.Init()
+
+	if err := p.ParseAll(); err != nil {
+		return nil, err
+	}
+	if err := p.execute(); err != nil {
+		return nil, err
+	}
+
+	return p.AST, nil
+}
+
+// execute builds the AST from the last successful parse. It returns
+// ParseErrors for the values that are invalid (ex: out of range port)
+func (p *Peg) execute() error {
+	p.Execute()
+	p.recordRaws()
+	if errs := p.AST.valueErrs; len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ParseError is a syntax error of the statement at Line
+type ParseError struct {
+	Line int
+	msg  string
+}
+
+func (e *ParseError) Error() string {
+	return e.msg
+}
+
+// ParseErrors gathers the syntax errors of all the bad statements
+// of a template, in the order they were found
+type ParseErrors []*ParseError
+
+// Error returns the errors one per line
+func (e ParseErrors) Error() string {
+	var all []string
+	for _, err := range e {
+		all = append(all, strings.TrimSuffix(err.Error(), "\n"))
+	}
+	return strings.Join(all, "\n")
+}
+
+// parseState is kept by the parser across the parses of ParseAll
+type parseState struct {
+	// first error of the last ParseAll, nil when it succeeded
+	firstErr *parseError
+}
+
+// ParseAll parses the Buffer reporting all the bad statements rather than
+// only the first one: after each failure the lines of the offending
+// statement are blanked out and the parse starts over. It returns ParseErrors on failure.
+// As with Parse, the parser must have been initialized or reset beforehand.
+func (p *Peg) ParseAll() error {
+	src, buffer := p.Buffer, p.buffer
+	defer func() { p.Buffer = src }()
+
+	var first *parseError
+	var errs ParseErrors
+	for {
+		err := p.Parse()
+		if err == nil {
+			break
+		}
+		perr, ok := err.(*parseError)
+		if !ok {
+			return err
+		}
+		if first == nil {
+			first = perr
+		}
+		line := 1 + strings.Count(string(p.buffer[:perr.max.end]), "\n")
+		errs = append(errs, &ParseError{Line: line, msg: perr.Error()})
+
+		blanked, ok := blankLines(p.Buffer, line, statementEndLine(p.Buffer, line))
+		if !ok || !p.alive() {
+			break
+		}
+		p.Buffer = blanked
+		p.Reset()
+	}
+
+	p.firstErr = first
+	if len(errs) > 0 {
+		// the first error remains the one detailed, on the original source
+		p.buffer = buffer
+		return errs
+	}
+	return nil
+}
+
+// ErrorDetail locates a parse error in the source. Lines and columns
+// start at 1, Rule is the furthest rule matched before failing and
+// Snippet the text it matched.
+type ErrorDetail struct {
+	Line, Col       int
+	EndLine, EndCol int
+	Rule, Snippet   string
+}
+
+// ParseErrorDetail returns the detail of the first error of the last
+// failed ParseAll
+func (p *Peg) ParseErrorDetail() (*ErrorDetail, bool) {
+	if p.firstErr == nil {
+		return nil, false
+	}
+	max := p.firstErr.max
+	begin, end := int(max.begin), int(max.end)
+	translations := translatePositions(p.buffer, []int{begin, end})
+	return &ErrorDetail{
+		Line:    translations[begin].line,
+		Col:     translations[begin].symbol,
+		EndLine: translations[end].line,
+		EndCol:  translations[end].symbol,
+		Rule:    rul3s[max.pegRule],
+		Snippet: string(p.buffer[begin:end]),
+	}, true
+}
+
+// PrintSyntaxTreeTo writes to w the syntax tree of the last successful
+// parse, as PrintSyntaxTree does to stdout
+func (p *Peg) PrintSyntaxTreeTo(w io.Writer) {
+	format := "%v %v\n"
+	if p.Pretty {
+		format = "\x1B[34m%v\x1B[m %v\n"
+	}
+	var print func(node *node32, depth int)
+	print = func(node *node32, depth int) {
+		for ; node != nil; node = node.next {
+			quote := strconv.Quote(string(p.buffer[node.begin:node.end]))
+			fmt.Fprintf(w, "%s"+format, strings.Repeat(" ", depth), rul3s[node.pegRule], quote)
+			print(node.up, depth+1)
+		}
+	}
+	print(p.tokens32.AST(), 0)
+}
+
+// blankLines replaces with spaces the content of the lines from first to last
+// (starting at 1), keeping positions of the other lines. It reports false
+// when there is nothing left to blank out.
+func blankLines(text string, first, last int) (string, bool) {
+	runes := []rune(text)
+	current, blanked := 1, false
+	for i, r := range runes {
+		if r == '\n' {
+			current++
+			continue
+		}
+		if current >= first && current <= last && r != ' ' {
+			runes[i], blanked = ' ', true
+		}
+	}
+	return string(runes), blanked
+}
+
+var heredocEndLine = regexp.MustCompile(`^EOF([^a-zA-Z0-9-._]|$)`)
+
+// statementEndLine returns the last line of the statement starting at line,
+// following line continuations and heredocs, unterminated heredocs running
+// until the end of the text
+func statementEndLine(text string, line int) int {
+	lines := strings.Split(text, "\n")
+	for end, heredoc := line, false; end <= len(lines); end++ {
+		current := strings.TrimSuffix(lines[end-1], "\r")
+		switch {
+		case heredoc:
+			heredoc = !heredocEndLine.MatchString(current)
+			if !heredoc && !strings.HasSuffix(current, "\\") {
+				return end
+			}
+		case strings.HasSuffix(current, "<<EOF"):
+			heredoc = true
+		case !strings.HasSuffix(current, "\\"):
+			return end
+		}
+	}
+	return len(lines)
+}
+
+// TokenSpan locates a meaningful token of a parsed template, with Begin
+// and End as byte offsets in the source
+type TokenSpan struct {
+	Rule       string
+	Begin, End int
+}
+
+// TokenSpans returns, in source order, the spans of the actions, entities,
+// declaration identifiers ("Identifier"), param keys ("ParamKey"),
+// values (ex: "StringValue", "RefValue") and comments ("Comment")
+// matched by the last successful Parse
+func (p *Peg) TokenSpans() (spans []TokenSpan) {
+	offsets := make([]int, 0, len(p.buffer)+1)
+	var offset int
+	for _, r := range []rune(p.Buffer) {
+		offsets = append(offsets, offset)
+		offset += len(string(r))
+	}
+	offsets = append(offsets, offset)
+
+	add := func(rule string, n *node32) {
+		spans = append(spans, TokenSpan{Rule: rule, Begin: offsets[n.begin], End: offsets[n.end]})
+	}
+
+	var walk func(n *node32, parent pegRule)
+	walk = func(n *node32, parent pegRule) {
+		for ; n != nil; n = n.next {
+			switch n.pegRule {
+			case ruleAction, ruleEntity:
+				add(rul3s[n.pegRule], n)
+			case ruleName:
+				if parent == ruleDeclaration {
+					add("Identifier", n)
+				}
+			case ruleIdentifier:
+				if parent == ruleParam {
+					add("ParamKey", n)
+				}
+			case ruleValue:
+				add(valueRule(n.up), n)
+			case ruleComment, ruleInlineComment:
+				add("Comment", n)
+			case rulePegText:
+				walk(n.up, parent)
+			default:
+				walk(n.up, n.pegRule)
+			}
+		}
+	}
+	walk(p.tokens32.AST(), ruleUnknown)
+
+	return
+}
+
+// ParseStats gives the size of the last successful Parse: the number of
+// syntax tokens and statements, the deepest nesting of the syntax tree
+// and the length of the source in bytes
+type ParseStats struct {
+	TokenCount, StatementCount, MaxDepth, ByteLen int
+}
+
+// Stats returns the statistics of the last successful Parse
+func (p *Peg) Stats() ParseStats {
+	stats := ParseStats{TokenCount: len(p.Tokens()), ByteLen: len(p.Buffer)}
+	var walk func(n *node32, depth int)
+	walk = func(n *node32, depth int) {
+		for ; n != nil; n = n.next {
+			if depth > stats.MaxDepth {
+				stats.MaxDepth = depth
+			}
+			switch n.pegRule {
+			case ruleExpr, ruleInclude, ruleVarAssignment:
+				stats.StatementCount++
+			}
+			walk(n.up, depth+1)
+		}
+	}
+	walk(p.tokens32.AST(), 1)
+	return stats
+}
+
+// recordRaws keeps the source text of the param values
+// of each expression of the AST in its Raw field
+func (p *Peg) recordRaws() {
+	var current int
+	var expr *ExpressionNode
+	var walk func(n *node32)
+	walk = func(n *node32) {
+		for ; n != nil; n = n.next {
+			switch n.pegRule {
+			case ruleExpr:
+				if current >= len(p.AST.Statements) {
+					return
+				}
+				expr = p.AST.Statements[current].expression()
+				current++
+				walk(n.up)
+			case ruleInclude, ruleVarAssignment:
+				current++
+			case ruleWithParams:
+				// copied into the statements of the block when parsed
+			case ruleParam:
+				var key string
+				for c := n.up; c != nil; c = c.next {
+					switch c.pegRule {
+					case rulePegText:
+						if key == "" {
+							key = string(p.buffer[c.begin:c.end])
+						}
+					case ruleValue:
+						if expr.Raw == nil {
+							expr.Raw = make(map[string]string)
+						}
+						expr.Raw[key] = string(p.buffer[c.begin:c.end])
+					}
+				}
+			default:
+				walk(n.up)
+			}
+		}
+	}
+	walk(p.tokens32.AST())
+}
+
+// valueRule returns the name of the concrete value rule under a Value node
+func valueRule(n *node32) string {
+	for ; n != nil; n = n.next {
+		if rule := rul3s[n.pegRule]; strings.HasSuffix(rule, "Value") && rule != "ItemValue" {
+			return rule
+		}
+		if rule := valueRule(n.up); rule != "" {
+			return rule
+		}
+	}
+	return ""
+}
+
+// markLine sets the line number of the current statement from the position
+// of its first token. As statements come in source order, lines are counted
+// incrementally from the previous statement.
+func (p *Peg) markLine(pos int) {
+	a := p.AST
+	for ; a.lineCursor < pos && a.lineCursor < len(p.buffer); a.lineCursor++ {
+		if p.buffer[a.lineCursor] == '\n' {
+			a.lineCount++
+		}
+	}
+	if st := a.currentStatement; st != nil && st.LineNumber == 0 {
+		st.LineNumber = a.lineCount + 1
+		st.BlankLines = p.blankLinesBefore(pos)
+	}
+}
+
+// blankLinesBefore counts the blank lines right above the line of pos,
+// or above the awless:disable directives of the statement at pos
+func (p *Peg) blankLinesBefore(pos int) (count int) {
+	i := pos - 1
+	for i >= 0 && p.buffer[i] != '\n' {
+		i--
+	}
+	for i >= 0 {
+		j := i - 1
+		for j >= 0 && p.buffer[j] != '\n' {
+			j--
+		}
+		if line := strings.TrimSpace(string(p.buffer[j+1 : i])); line != "" {
+			if count > 0 || !isDisableDirective(line) {
+				return
+			}
+			i = j
+			continue
+		}
+		count++
+		i = j
+	}
+	return
+}
+
+// alive is checked by the grammar at each statement and param
+func (p *Peg) alive() bool {
+	select {
+	case <-p.done:
+		return false
+	default:
+		return true
+	}
+}
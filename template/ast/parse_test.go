@@ -0,0 +1,489 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseReader(t *testing.T) {
+	tree, err := ParseReader(strings.NewReader("myvpc = create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$myvpc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(tree.Statements), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := tree.Statements[1].String(), "create subnet vpc=$myvpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err := ParseReader(strings.NewReader("create vpc cidr=")); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-template")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "template.awless")
+	if err := ioutil.WriteFile(path, []byte("create vpc cidr=10.0.0.0/16\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.Statements[0].Entity(), "vpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err := ParseFile(filepath.Join(dir, "missing.awless")); !os.IsNotExist(err) {
+		t.Fatalf("expected not exist error, got %v", err)
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	tree, err := ParseContext(context.Background(), "create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$myvpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(tree.Statements), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ParseContext(ctx, "create vpc cidr=10.0.0.0/16"); err != context.Canceled {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+
+	var lines []string
+	for i := 0; i < 100000; i++ {
+		lines = append(lines, "myinstance = create instance type=t2.micro count=3 subnet=$mysubnet name={instance.name}")
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := ParseContext(ctx, strings.Join(lines, "\n")); err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// mustParsePeg parses src with a new parser, for tests inspecting the parser
+func mustParsePeg(t *testing.T, src string) *Peg {
+	p := &Peg{AST: &AST{}, Buffer: src}
+	p.Init()
+	if err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestTokenSpans(t *testing.T) {
+	src := "myvpc = create vpc cidr=10.0.0.0/16 # main\ncreate subnet vpc=$myvpc name='é s'"
+	p := mustParsePeg(t, src)
+
+	expected := []struct {
+		rule, text string
+	}{
+		{"Identifier", "myvpc"},
+		{"Action", "create"},
+		{"Entity", "vpc"},
+		{"ParamKey", "cidr"},
+		{"CidrValue", "10.0.0.0/16"},
+		{"Comment", "# main"},
+		{"Action", "create"},
+		{"Entity", "subnet"},
+		{"ParamKey", "vpc"},
+		{"RefValue", "$myvpc"},
+		{"ParamKey", "name"},
+		{"SingleQuotedValue", "'é s'"},
+	}
+
+	spans := p.TokenSpans()
+	if got, want := len(spans), len(expected); got != want {
+		t.Fatalf("got %d (%v), want %d", got, spans, want)
+	}
+	for i, span := range spans {
+		if got, want := span.Rule, expected[i].rule; got != want {
+			t.Fatalf("%d: got %s, want %s", i, got, want)
+		}
+		if got, want := src[span.Begin:span.End], expected[i].text; got != want {
+			t.Fatalf("%d: got %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestParseReportsAllErrors(t *testing.T) {
+	_, err := Parse("create vpc cidr=10.0.0.0/16\ncreate subnet name=%bad\ncreate vpc\n!!! instance\ncreate vpc")
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	errs, ok := err.(ParseErrors)
+	if !ok {
+		t.Fatalf("got %T, want ParseErrors", err)
+	}
+	if got, want := len(errs), 2; got != want {
+		t.Fatalf("got %d (%s), want %d", got, err, want)
+	}
+	if got, want := errs[0].Line, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := errs[1].Line, 4; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "line 2 symbol") || !strings.Contains(msg, "line 4 symbol") {
+		t.Fatalf("expected both lines in error, got %s", msg)
+	}
+
+	if _, err := Parse("create vpc\ncreate subnet"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrintSyntaxTreeTo(t *testing.T) {
+	p := mustParsePeg(t, "create vpc cidr=10.0.0.0/16")
+
+	var buff bytes.Buffer
+	p.PrintSyntaxTreeTo(&buff)
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), "\n")
+	if got, want := lines[0], `Script "create vpc cidr=10.0.0.0/16"`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	for _, want := range []string{` Action "create"`, ` Entity "vpc"`, ` CidrValue "10.0.0.0/16"`} {
+		if !strings.Contains(buff.String(), want) {
+			t.Fatalf("expected %q in tree\n%s", want, buff.String())
+		}
+	}
+}
+
+func TestParseErrorDetail(t *testing.T) {
+	p := &Peg{AST: &AST{}, Buffer: "create vpc\ncreate subnet name=%bad"}
+	p.Init()
+	if _, ok := p.ParseErrorDetail(); ok {
+		t.Fatal("expected no error detail before parsing")
+	}
+	if err := p.ParseAll(); err == nil {
+		t.Fatal("expected error got none")
+	}
+
+	detail, ok := p.ParseErrorDetail()
+	if !ok {
+		t.Fatal("expected error detail")
+	}
+	if got, want := *detail, (ErrorDetail{Line: 2, Col: 19, EndLine: 2, EndCol: 20, Rule: "Equal", Snippet: "="}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	p.Buffer = "create vpc\ncreate subnet"
+	p.Reset()
+	if err := p.ParseAll(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.ParseErrorDetail(); ok {
+		t.Fatal("expected no error detail after successful parse")
+	}
+
+	p.Buffer = "create vpc cidr=%\ncreate subnet name=%bad"
+	p.Reset()
+	if err := p.ParseAll(); err == nil {
+		t.Fatal("expected error got none")
+	}
+	if detail, _ := p.ParseErrorDetail(); detail.Line != 1 || detail.Snippet != "=" {
+		t.Fatalf("got %+v, want first error detail", detail)
+	}
+}
+
+func TestNormalizeKeywords(t *testing.T) {
+	tcases := []struct {
+		src, expect string
+	}{
+		{src: "Create VPC", expect: "create vpc"},
+		{src: "create tags value=MyName", expect: "create tags value=MyName"},
+		{src: "myVpc = CREATE Vpc Name=MyVpc\n  Create Subnet vpc=$myVpc; Delete Instance id=@My-Instance", expect: "myVpc = create vpc Name=MyVpc\n  create subnet vpc=$myVpc; delete instance id=@My-Instance"},
+		{src: "When {Large} Create Instance name='Create VPC\nCreate VPC'", expect: "when {Large} create instance name='Create VPC\nCreate VPC'"},
+		{src: "WHEN {n} >= 2 Create Instance", expect: "when {n} >= 2 create instance"},
+		{src: "# Create VPC\n/* Create VPC\n Create VPC */ Create VPC // Create VPC", expect: "# Create VPC\n/* Create VPC\n Create VPC */ create vpc // Create VPC"},
+		{src: "create keypair name=Key \\\nEncrypted Yes=1", expect: "create keypair name=Key \\\nEncrypted Yes=1"},
+		{src: "create policy document=<<EOF\nCreate VPC\nEOF\nStart Instance", expect: "create policy document=<<EOF\nCreate VPC\nEOF\nstart instance"},
+	}
+	for i, tcase := range tcases {
+		if got, want := NormalizeKeywords(tcase.src), tcase.expect; got != want {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+
+	if _, err := Parse(NormalizeKeywords("Create VPC cidr=10.0.0.0/16")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRawValues(t *testing.T) {
+	tree, err := Parse("create securitygroup port=0x1F cidr=10.0.0.0/16 name='my group' vpc=$myvpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expr := tree.Statements[0].Node.(*ExpressionNode)
+
+	if got, want := expr.Params["port"], Port(31); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	expected := map[string]string{"port": "0x1F", "cidr": "10.0.0.0/16", "name": "'my group'", "vpc": "$myvpc"}
+	if got, want := expr.Raw, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	reparsed, err := Parse(tree.RawString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reparsed.Statements[0].Node.(*ExpressionNode).Raw, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if !strings.Contains(tree.String(), "port=31") {
+		t.Fatalf("expected printed value in %s", tree)
+	}
+
+	expr.ProcessRefs(map[string]interface{}{"myvpc": "vpc-1234"})
+	if got, want := expr.RawString(), "vpc=vpc-1234"; !strings.Contains(got, want) {
+		t.Fatalf("got %s, want %s in it", got, want)
+	}
+}
+
+func TestParseWithBlock(t *testing.T) {
+	tree, err := Parse(`create keypair name=mykey
+with region=us-east-1 type={instance.type} {
+  myvpc = create vpc cidr=10.0.0.0/16
+  # instances of the block
+  create instance name=web region=eu-west-1
+}
+create subnet vpc=$myvpc`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, st := range tree.Statements {
+		got = append(got, fmt.Sprintf("%d: %s", st.LineNumber, (&AST{Statements: []*Statement{st}}).Canonical()))
+	}
+	want := []string{
+		"1: create keypair name=mykey",
+		"3: myvpc = create vpc cidr=10.0.0.0/16 region=us-east-1 type={instance.type}",
+		"5: create instance name=web region=eu-west-1 type={instance.type}",
+		"7: create subnet vpc=$myvpc",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := len(tree.OrderedHoles()), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	if _, err := Parse("with region=us-east-1 create vpc"); err == nil {
+		t.Fatal("expected error for with block without braces")
+	}
+}
+
+func TestParseStats(t *testing.T) {
+	src := "myvpc = create vpc cidr=10.0.0.0/16 # main\ncreate subnet vpc=$myvpc name='é s'"
+	p := mustParsePeg(t, src)
+	stats := p.Stats()
+	if got, want := stats.StatementCount, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := stats.ByteLen, len(src); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := stats.TokenCount, len(p.Tokens()); got != want || got < 20 {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if stats.MaxDepth < 5 || stats.MaxDepth > stats.TokenCount {
+		t.Fatalf("unexpected max depth %d for %d tokens", stats.MaxDepth, stats.TokenCount)
+	}
+
+	src = "var a=1, b=2\ninclude \"base.aws\"\nwith region=eu-west-1 {\n  create vpc cidr=10.0.0.0/16\n}"
+	p = mustParsePeg(t, src)
+	p.Execute()
+	if got, want := p.Stats().StatementCount, len(p.AST.Statements); got != want || got != 4 {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestParseInclude(t *testing.T) {
+	tree, err := Parse("create vpc name=a\n\ninclude \"envs/prod \\\"eu\\\".aws\" # shared\ncreate subnet name=b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(tree.Statements), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	st := tree.Statements[1]
+	if got, want := st.Kind(), KindInclude; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := st.Node.(*IncludeNode).Path, `envs/prod "eu".aws`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := st.LineNumber, 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := tree.String(), "create vpc name=a\n\ninclude \"envs/prod \\\"eu\\\".aws\"\ncreate subnet name=b"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tree.Statements[2].LineNumber, 4; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestBlankLinesPreserved(t *testing.T) {
+	src := "myvpc = create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$myvpc\n\n\n# instances\ncreate instance name=web\n  \t\ncreate instance name=db\n\n"
+	tree, err := Parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blanks []int
+	for _, st := range tree.Statements {
+		blanks = append(blanks, st.BlankLines)
+	}
+	if got, want := blanks, []int{0, 0, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	src = "create vpc cidr=10.0.0.0/16\ncreate subnet cidr=10.0.0.0/24\n\n\ncreate instance name=web\ncreate instance name=db"
+	tree, err = Parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.String(), src; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := tree.Clone().RawString(), src; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := tree.Canonical(), strings.Replace(src, "\n\n\n", "\n", 1); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestDuplicateParamKeys(t *testing.T) {
+	_, err := Parse("create vpc cidr=10.0.0.0/8 name=main\ncreate vpc cidr=10.0.0.0/8 cidr=10.1.0.0/8\ncreate subnet vpc=$myvpc vpc={vpc}")
+	errs, ok := err.(ParseErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("got %#v, want 2 parse errors", err)
+	}
+	if got, want := errs[0].Error(), "line 2: param 'cidr' specified more than once"; !strings.Contains(got, want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := errs[1].Line, 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	if _, err := Parse("create vpc cidr=10.0.0.0/8 name=main\ncreate vpc cidr=10.1.0.0/8 name=main"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseReportsMultiLineStatementErrorsOnce(t *testing.T) {
+	tcases := []struct {
+		src   string
+		lines []int
+	}{
+		{src: "create vpc\ncreate policy document=<<EOF\n{\"a\": \"b\"}\n!!! bad\ncreate subnet\n\ncreate instance name=%x", lines: []int{2}},
+		{src: "create policy !!! document=<<EOF\n!!! bad\nEOF\ncreate vpc\ncreate instance name=%x", lines: []int{1, 5}},
+		{src: "create keypair name=%x \\\n!!! bad\ncreate vpc\ncreate instance name=%x", lines: []int{1, 4}},
+	}
+	for _, tcase := range tcases {
+		_, err := Parse(tcase.src)
+		errs, ok := err.(ParseErrors)
+		if !ok {
+			t.Fatalf("%q: got %v, want ParseErrors", tcase.src, err)
+		}
+		var lines []int
+		for _, err := range errs {
+			lines = append(lines, err.Line)
+		}
+		if got, want := lines, tcase.lines; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%q: got %v, want %v", tcase.src, got, want)
+		}
+	}
+}
+
+func TestParseErrorsOnePerLine(t *testing.T) {
+	_, err := Parse("create instance port=70000\ncreate instance port=99999")
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	if got, want := err.Error(), "line 1: invalid port '70000': expecting 0-65535\nline 2: invalid port '99999': expecting 0-65535"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestPreviousResultRef(t *testing.T) {
+	tree, err := Parse("create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tree.Statements[1].expression().Refs["vpc"], PreviousResultRef; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	_, err = Parse("\ncreate subnet vpc=$_\ncreate instance subnet=$_")
+	errs, ok := err.(ParseErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("got %#v, want 1 parse error", err)
+	}
+	if got, want := errs[0].Error(), "line 2: '$_' refers to the result of the previous statement: none before the first statement"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err = Parse("_ = create vpc"); err == nil || !strings.Contains(err.Error(), "cannot declare '_'") {
+		t.Fatalf("got %v, want reserved identifier error", err)
+	}
+}
+
+func TestParseDisableDirective(t *testing.T) {
+	tree := mustParse(t, "# awless:disable missing-param, param-type\ncreate vpc\n// just a comment\ncreate subnet\n//awless:disable duplicate-identifier\nmyvpc = create vpc\nmyvpc = create vpc")
+	if got, want := tree.Statements[0].DisabledChecks, []string{"missing-param", "param-type"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got := tree.Statements[1].DisabledChecks; len(got) != 0 {
+		t.Fatalf("got %q, want none", got)
+	}
+	if !tree.Statements[2].Disables("duplicate-identifier") || tree.Statements[3].Disables("duplicate-identifier") {
+		t.Fatal("expected duplicate-identifier disabled on the third statement only")
+	}
+	if errs := tree.Validate(); len(errs) != 1 {
+		t.Fatalf("got %v, want 1 error", errs)
+	}
+	if errs := mustParse(t, "myvpc = create vpc\n# awless:disable duplicate-identifier\nmyvpc = create vpc").Validate(); len(errs) != 0 {
+		t.Fatalf("got %v, want none", errs)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "sync"
+
+// ParserPool recycles parsers for concurrent parsing.
+//
+// A Peg holds the mutable state of the parse in progress (buffer, tokens, AST)
+// and must never be shared between goroutines: each parse gets its own parser
+// from the pool and gives it back once done.
+type ParserPool struct {
+	pool sync.Pool
+}
+
+func NewParserPool() *ParserPool {
+	return &ParserPool{pool: sync.Pool{
+		New: func() interface{} {
+			p := &Peg{AST: &AST{}, Pretty: true}
+			p.Init()
+			return p
+		},
+	}}
+}
+
+// Get returns a clean parser. Set its Buffer and call Reset before parsing.
+func (pp *ParserPool) Get() *Peg {
+	return pp.pool.Get().(*Peg)
+}
+
+// Put resets the parser and gives it back to the pool.
+// The parser, and its AST, must not be used afterwards.
+func (pp *ParserPool) Put(p *Peg) {
+	p.AST = &AST{}
+	p.Buffer = ""
+	p.firstErr = nil
+	p.Reset()
+	pp.pool.Put(p)
+}
+
+// Parse parses text as Parse does, with a parser of the pool
+func (pp *ParserPool) Parse(text string) (*AST, error) {
+	p := pp.Get()
+	defer pp.Put(p)
+
+	p.Buffer = text
+	p.Reset()
+	// ParseAll errors are rendered eagerly so they do not
+	// depend on the parser state once it goes back to the pool
+	if err := p.ParseAll(); err != nil {
+		return nil, err
+	}
+	if err := p.execute(); err != nil {
+		return nil, err
+	}
+
+	return p.AST, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestParserPoolConcurrentParsing(t *testing.T) {
+	pool := NewParserPool()
+
+	var wg sync.WaitGroup
+	errc := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			text := fmt.Sprintf("myvpc = create vpc cidr=10.0.%d.0/24\ncreate subnet vpc=$myvpc count=%d", i, i)
+			if i%10 == 0 {
+				text = "create vpc cidr="
+			}
+			tree, err := pool.Parse(text)
+			if i%10 == 0 {
+				if err == nil {
+					errc <- fmt.Errorf("%d: expected error got none", i)
+				}
+				return
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+			if got, want := len(tree.Statements), 2; got != want {
+				errc <- fmt.Errorf("%d: got %d, want %d", i, got, want)
+				return
+			}
+			if got, want := tree.Statements[1].Params()["count"], i; got != want {
+				errc <- fmt.Errorf("got %v, want %v", got, want)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errc)
+
+	for err := range errc {
+		t.Fatal(err)
+	}
+}
+
+func TestParserPoolGetAndPut(t *testing.T) {
+	pool := NewParserPool()
+
+	p := pool.Get()
+	p.Buffer = "create vpc\ncreate subnet"
+	p.Reset()
+	if err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	p.Execute()
+	if got, want := len(p.Statements), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	pool.Put(p)
+
+	if got, want := len(p.Statements), 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	p = pool.Get()
+	p.Buffer = "create vpc cidr="
+	p.Reset()
+	if err := p.ParseAll(); err == nil {
+		t.Fatal("expected error got none")
+	}
+	pool.Put(p)
+
+	if _, ok := p.ParseErrorDetail(); ok {
+		t.Fatal("expected no error detail once put back")
+	}
+}
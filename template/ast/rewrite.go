@@ -0,0 +1,53 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Rewrite calls fn on every node of the template, children first (ex: the
+// identifiers and expression of a declaration before the declaration), and
+// replaces each node with the one returned. fn returns the node it was given
+// to leave it unchanged. The template is rewritten in place and returned.
+func Rewrite(a *AST, fn func(Node) Node) *AST {
+	for _, st := range a.Statements {
+		st.Node = rewrite(st.Node, fn)
+	}
+	return a
+}
+
+func rewrite(n Node, fn func(Node) Node) Node {
+	if decl, ok := n.(*DeclarationNode); ok {
+		decl.Left = rewriteAs(decl.Left, fn).(*IdentifierNode)
+		for i, ident := range decl.Extra {
+			decl.Extra[i] = rewriteAs(ident, fn).(*IdentifierNode)
+		}
+		decl.Right = rewriteAs(decl.Right, fn).(*ExpressionNode)
+	}
+	return fn(n)
+}
+
+// rewriteAs rewrites a child node that must keep its type to fit its parent
+func rewriteAs(n Node, fn func(Node) Node) Node {
+	rewritten := rewrite(n, fn)
+	if reflect.TypeOf(rewritten) != reflect.TypeOf(n) {
+		panic(fmt.Sprintf("rewrite: cannot replace %T with %T", n, rewritten))
+	}
+	return rewritten
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	tree := mustParse(t, "myvpc = create vpc cidr=10.0.0.0/16\nwhen {large} create instance type=t2.large\ndelete subnet id=$mysubnet\ninclude \"other.aws\"")
+	guard := tree.Statements[1].Guard
+
+	var visited []string
+	rewritten := Rewrite(tree, func(n Node) Node {
+		visited = append(visited, fmt.Sprintf("%T", n))
+		if expr, ok := n.(*ExpressionNode); ok && expr.Action == "create" {
+			clone := expr.clone().(*ExpressionNode)
+			clone.Action = "ensure"
+			return clone
+		}
+		return n
+	})
+
+	if rewritten != tree {
+		t.Fatal("expected template rewritten in place")
+	}
+	if got, want := tree.String(), "myvpc = ensure vpc cidr=10.0.0.0/16\nwhen {large} ensure instance type=t2.large\ndelete subnet id=$mysubnet\ninclude \"other.aws\""; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := strings.Join(visited, " "), "*ast.IdentifierNode *ast.ExpressionNode *ast.DeclarationNode *ast.ExpressionNode *ast.ExpressionNode *ast.IncludeNode"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if tree.Statements[1].Guard != guard {
+		t.Fatal("expected statement guard to be kept")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic replacing a declaration expression with an identifier")
+		}
+	}()
+	Rewrite(mustParse(t, "myvpc = create vpc"), func(n Node) Node {
+		if _, ok := n.(*ExpressionNode); ok {
+			return &IdentifierNode{Ident: "oops"}
+		}
+		return n
+	})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FromStruct builds a create statement of the entity with params read from
+// the exported fields of a struct (or pointer to struct), keyed by their
+// `awless:"key"` tag or else their lowercased name. Fields tagged "-" are
+// skipped, as are zero values of fields tagged with the omitempty option
+// (ex: `awless:"count,omitempty"`). Supported fields are strings, numbers,
+// booleans, string slices and pointers to those, ints of port keys
+// becoming ports as when parsed.
+func FromStruct(entity string, v interface{}) (*Statement, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("from struct: expecting a struct, got %T", v)
+	}
+
+	expr := &ExpressionNode{
+		Action: "create", Entity: entity,
+		Refs:    make(map[string]string),
+		Params:  make(map[string]interface{}),
+		Aliases: make(map[string]string),
+		Holes:   make(map[string]string),
+	}
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		key, omitempty := strings.ToLower(field.Name), false
+		if tag, ok := field.Tag.Lookup("awless"); ok {
+			opts := strings.Split(tag, ",")
+			if opts[0] == "-" {
+				continue
+			}
+			if opts[0] != "" {
+				key = opts[0]
+			}
+			for _, opt := range opts[1:] {
+				omitempty = omitempty || opt == "omitempty"
+			}
+		}
+
+		fieldVal := val.Field(i)
+		if omitempty && reflect.DeepEqual(fieldVal.Interface(), reflect.Zero(field.Type).Interface()) {
+			continue
+		}
+		for fieldVal.Kind() == reflect.Ptr && !fieldVal.IsNil() {
+			fieldVal = fieldVal.Elem()
+		}
+		if fieldVal.Kind() == reflect.Ptr {
+			continue
+		}
+		param, err := structParamValue(fieldVal)
+		if err != nil {
+			return nil, fmt.Errorf("from struct: field %s: %s", field.Name, err)
+		}
+		if num, ok := param.(int); ok && isPortKey(key) {
+			if param, err = parsePort(strconv.Itoa(num)); err != nil {
+				return nil, fmt.Errorf("from struct: field %s: %s", field.Name, err)
+			}
+		}
+		expr.Params[key] = param
+	}
+
+	return &Statement{Node: expr}, nil
+}
+
+// structParamValue converts field values into the types the parser gives
+// to params so that generated statements round trip
+func structParamValue(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			strs := make([]string, v.Len())
+			for i := range strs {
+				strs[i] = v.Index(i).String()
+			}
+			return strs, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %s", v.Type())
+}
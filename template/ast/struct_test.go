@@ -0,0 +1,78 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast
+
+import "testing"
+
+func TestFromStruct(t *testing.T) {
+	type config struct {
+		Type     string   `awless:"type"`
+		Count    int      `awless:"count"`
+		Name     string   `awless:"name,omitempty"`
+		Key      string   `awless:"key,omitempty"`
+		Groups   []string `awless:"group"`
+		Lock     bool     `awless:"lock"`
+		Ratio    *float64 `awless:"ratio,omitempty"`
+		Subnet   string
+		Internal string `awless:"-"`
+		hidden   string
+	}
+	ratio := 0.5
+	cfg := &config{Type: "t2.micro", Count: 2, Name: "my instance", Groups: []string{"sg-1", "sg-2"}, Lock: true, Ratio: &ratio, Subnet: "subnet-1234", Internal: "x", hidden: "y"}
+
+	st, err := FromStruct("instance", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := &AST{Statements: []*Statement{st}}
+	if got, want := tree.Canonical(), "create instance count=2 group=sg-1,sg-2 lock=true name='my instance' ratio=0.5 subnet=subnet-1234 type=t2.micro"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := len(st.Params()), 7; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	reparsed := mustParse(t, tree.String())
+	if got, want := reparsed.Canonical(), tree.Canonical(); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	type listener struct {
+		Port     int    `awless:"port"`
+		Protocol string `awless:"protocol"`
+	}
+	st, err = FromStruct("listener", listener{Port: 443, Protocol: "HTTPS"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := st.Params()["port"], Port(443); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	tree = &AST{Statements: []*Statement{st}}
+	if reparsed := mustParse(t, tree.String()); !reparsed.Equal(tree) {
+		t.Fatalf("round trip failed, got %s", reparsed)
+	}
+	if _, err := FromStruct("listener", listener{Port: 70000}); err == nil {
+		t.Fatal("expected error got none")
+	}
+
+	if _, err := FromStruct("instance", "t2.micro"); err == nil {
+		t.Fatal("expected error got none")
+	}
+	if _, err := FromStruct("instance", struct{ Tags map[string]string }{}); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package aws
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -49,9 +50,9 @@ func NewDriver(ec2, iam, s3 interface{}) *AwsDriver {
 func (d *AwsDriver) SetDryRun(dry bool)         { d.dryRun = dry }
 func (d *AwsDriver) SetLogger(l *logger.Logger) { d.logger = l }
 
-func (d *AwsDriver) Lookup(lookups ...string) driver.DriverFn {
+func (d *AwsDriver) Lookup(lookups ...string) (driver.DriverFn, error) {
 	if len(lookups) < 2 {
-		panic("need at least 2 string to lookup driver method")
+		return nil, errors.New("need at least 2 string to lookup driver method")
 	}
 
 	var format string
@@ -62,14 +63,17 @@ func (d *AwsDriver) Lookup(lookups ...string) driver.DriverFn {
 	}
 
 	fnName := fmt.Sprintf(format, humanize(lookups[0]), humanize(lookups[1]))
-	method := reflect.ValueOf(d).MethodByName(fnName).Interface()
+	method := reflect.ValueOf(d).MethodByName(fnName)
+	if !method.IsValid() {
+		return nil, fmt.Errorf("%s %s: unsupported by the aws driver", lookups[0], lookups[1])
+	}
 
-	driverFn, converted := method.(func(map[string]interface{}) (interface{}, error))
+	driverFn, converted := method.Interface().(func(map[string]interface{}) (interface{}, error))
 	if !converted {
-		panic(fmt.Sprintf("method '%s' found on '%T' is not a driver function", fnName, d))
+		return nil, fmt.Errorf("method '%s' found on '%T' is not a driver function", fnName, d)
 	}
 
-	return driverFn
+	return driverFn, nil
 }
 
 func humanize(s string) string {
@@ -34,6 +34,7 @@ import (
 	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/console"
 	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template/ast"
 )
 
 func (d *AwsDriver) Check_Instance_DryRun(params map[string]interface{}) (interface{}, error) {
@@ -92,8 +93,8 @@ func (d *AwsDriver) Check_Instance(params map[string]interface{}) (interface{},
 			if res := output.Reservations; len(res) > 0 {
 				if instances := output.Reservations[0].Instances; len(instances) > 0 {
 					for _, inst := range instances {
-						if aws.StringValue(inst.InstanceId) == params["id"] {
-							if aws.StringValue(inst.State.Name) == params["state"] {
+						if aws.StringValue(inst.InstanceId) == fmt.Sprint(params["id"]) {
+							if aws.StringValue(inst.State.Name) == fmt.Sprint(params["state"]) {
 								d.logger.Verbose("check instance status '%s' done", params["state"])
 								return nil, nil
 							}
@@ -364,8 +365,8 @@ func (d *AwsDriver) Create_Storageobject(params map[string]interface{}) (interfa
 	input.Body = f
 
 	var fileName string
-	if n, ok := params["name"].(string); ok && n != "" {
-		fileName = n
+	if n, ok := params["name"]; ok && fmt.Sprint(n) != "" {
+		fileName = fmt.Sprint(n)
 	} else {
 		fileName = f.Name()
 	}
@@ -409,6 +410,12 @@ func buildIpPermissionsFromParams(params map[string]interface{}) ([]*ec2.IpPermi
 	case int64:
 		ipPerm.FromPort = aws.Int64(ports)
 		ipPerm.ToPort = aws.Int64(ports)
+	case ast.Port:
+		ipPerm.FromPort = aws.Int64(int64(ports))
+		ipPerm.ToPort = aws.Int64(int64(ports))
+	case ast.Range:
+		ipPerm.FromPort = aws.Int64(int64(ports.Lo))
+		ipPerm.ToPort = aws.Int64(int64(ports.Hi))
 	case string:
 		switch {
 		case strings.Contains(ports, "any"):
@@ -27,6 +27,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/wallix/awless/template/ast"
 )
 
 func TestHumanizeString(t *testing.T) {
@@ -44,6 +45,34 @@ func TestHumanizeString(t *testing.T) {
 	}
 }
 
+func TestLookup(t *testing.T) {
+	driv := NewDriver(&mockEc2{}, &mockIam{}, &mockS3{})
+
+	if fn, err := driv.Lookup("create", "vpc"); err != nil || fn == nil {
+		t.Fatalf("got %v, want create vpc driver function", err)
+	}
+	driv.SetDryRun(true)
+	if fn, err := driv.Lookup("create", "vpc"); err != nil || fn == nil {
+		t.Fatalf("got %v, want create vpc dry run driver function", err)
+	}
+	driv.SetDryRun(false)
+
+	tcases := []struct {
+		lookups []string
+		err     string
+	}{
+		{[]string{"ensure", "instance"}, "ensure instance: unsupported by the aws driver"},
+		{[]string{"craete", "vpc"}, "craete vpc: unsupported by the aws driver"},
+		{[]string{"create", "vppc"}, "create vppc: unsupported by the aws driver"},
+		{[]string{"create"}, "need at least 2 string to lookup driver method"},
+	}
+	for _, tcase := range tcases {
+		if _, err := driv.Lookup(tcase.lookups...); err == nil || err.Error() != tcase.err {
+			t.Fatalf("%v: got %v, want %s", tcase.lookups, err, tcase.err)
+		}
+	}
+}
+
 func TestDriver(t *testing.T) {
 	awsMock := &mockEc2{}
 	driv := NewDriver(awsMock, &mockIam{}, &mockS3{})
@@ -138,6 +167,33 @@ func TestDriver(t *testing.T) {
 			t.Fatalf("got %t, want %t", got, want)
 		}
 	})
+
+	t.Run("Create volume", func(t *testing.T) {
+		tcases := []struct {
+			size ast.Size
+			gib  int64
+		}{
+			{ast.Size{Value: 100, Unit: "gb"}, 100},
+			{ast.Size{Value: 2, Unit: "tb"}, 2048},
+			{ast.Size{Value: 512, Unit: "mb"}, 1},
+		}
+		for _, tcase := range tcases {
+			awsMock.verifyVolumeInput = func(input *ec2.CreateVolumeInput) error {
+				if got, want := aws.Int64Value(input.Size), tcase.gib; got != want {
+					return fmt.Errorf("%s: got %d, want %d", tcase.size, got, want)
+				}
+				return nil
+			}
+
+			id, err := driv.Create_Volume(map[string]interface{}{"zone": "eu-west-1a", "size": tcase.size})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := id.(string), "mynewvolume"; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+		}
+	})
 }
 
 func TestBuildIpPermissionsFromParams(t *testing.T) {
@@ -239,6 +295,7 @@ type mockEc2 struct {
 	verifySubnetInput   func(*ec2.CreateSubnetInput) error
 	verifyInstanceInput func(*ec2.RunInstancesInput) error
 	verifyTagInput      func(*ec2.CreateTagsInput) error
+	verifyVolumeInput   func(*ec2.CreateVolumeInput) error
 }
 
 func (m *mockEc2) CreateVpc(input *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
@@ -262,6 +319,13 @@ func (m *mockEc2) RunInstances(input *ec2.RunInstancesInput) (*ec2.Reservation,
 	return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: aws.String("mynewinstance")}}}, nil
 }
 
+func (m *mockEc2) CreateVolume(input *ec2.CreateVolumeInput) (*ec2.Volume, error) {
+	if err := m.verifyVolumeInput(input); err != nil {
+		return nil, err
+	}
+	return &ec2.Volume{VolumeId: aws.String("mynewvolume")}, nil
+}
+
 func (m *mockEc2) CreateTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
 	if err := m.verifyTagInput(input); err != nil {
 		return nil, err
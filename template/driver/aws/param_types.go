@@ -0,0 +1,40 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import "github.com/wallix/awless/template"
+
+// paramTypes gives the type of params by name, as param names
+// are used consistently across AWS template definitions
+var paramTypes = map[string]string{
+	"cidr": template.CidrParam,
+	"ip":   template.IPParam,
+}
+
+func init() {
+	for key, def := range AWSTemplatesDefinitions {
+		for _, param := range append(def.Required(), def.Extra()...) {
+			if typ, ok := paramTypes[param]; ok {
+				if def.ParamTypes == nil {
+					def.ParamTypes = make(map[string]string)
+				}
+				def.ParamTypes[param] = typ
+			}
+		}
+		AWSTemplatesDefinitions[key] = def
+	}
+}
@@ -18,18 +18,20 @@ package aws
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/template/ast"
 )
 
 func setField(s, i interface{}, fieldName string) {
 	sval := reflect.ValueOf(s)
 	ival := reflect.ValueOf(i)
 
-	if !ival.IsValid() || !sval.IsValid() {
+	if !ival.IsValid() || !sval.IsValid() || s == ast.Null {
 		return
 	}
 
@@ -44,6 +46,13 @@ func setField(s, i interface{}, fieldName string) {
 		return
 	}
 
+	typed := s
+
+	// typed template values (ex: ARN) are set with their string form
+	if str, ok := s.(fmt.Stringer); ok {
+		s = str.String()
+	}
+
 	var stringptr *string
 	var int64ptr *int64
 	var boolptr *bool
@@ -53,7 +62,16 @@ func setField(s, i interface{}, fieldName string) {
 	if fieldVal.Kind() == reflect.Ptr {
 		switch fieldVal.Type() {
 		case reflect.TypeOf(stringptr):
-			fieldVal.Set(reflect.ValueOf(aws.String(s.(string))))
+			switch ss := s.(type) {
+			case string:
+				fieldVal.Set(reflect.ValueOf(aws.String(ss)))
+			case ast.Color:
+				fieldVal.Set(reflect.ValueOf(aws.String(string(ss))))
+			case int, int64, float64, ast.Port, bool:
+				fieldVal.Set(reflect.ValueOf(aws.String(fmt.Sprint(ss))))
+			default:
+				panic(fmt.Sprintf("cannot set %T on string field %s", typed, fieldName))
+			}
 		case reflect.TypeOf(boolval), reflect.TypeOf(boolptr):
 			var b bool
 			var err error
@@ -65,6 +83,8 @@ func setField(s, i interface{}, fieldName string) {
 				}
 			case bool:
 				b = ss
+			default:
+				panic(fmt.Sprintf("cannot set %T on bool field %s", typed, fieldName))
 			}
 			if fieldVal.Type() == reflect.TypeOf(boolval) {
 				boolval = &ec2.AttributeBooleanValue{Value: aws.Bool(b)}
@@ -79,16 +99,29 @@ func setField(s, i interface{}, fieldName string) {
 		case reflect.TypeOf(int64ptr):
 			var r int64
 			var err error
-			switch s.(type) {
+			switch ss := typed.(type) {
 			case string:
-				r, err = strconv.ParseInt(s.(string), 10, 64)
+				r, err = strconv.ParseInt(ss, 10, 64)
 				if err != nil {
 					panic(err)
 				}
 			case int:
-				r = int64(s.(int))
+				r = int64(ss)
 			case int64:
-				r = s.(int64)
+				r = ss
+			case ast.Port:
+				r = int64(ss)
+			case float64:
+				if ss != math.Trunc(ss) || ss < math.MinInt64 || ss >= math.MaxInt64 {
+					panic(fmt.Sprintf("cannot set float %v on int field %s", ss, fieldName))
+				}
+				r = int64(ss)
+			case ast.Size:
+				// numeric sizes are in GiB (ex: volume size)
+				r = ss.GiB()
+			default:
+				// ranges, percentages, ... have no single int value
+				panic(fmt.Sprintf("cannot set %T '%v' on int field %s", typed, typed, fieldName))
 			}
 			fieldVal.Set(reflect.ValueOf(aws.Int64(int64(r))))
 		}
@@ -99,12 +132,28 @@ func setField(s, i interface{}, fieldName string) {
 		case string:
 			slice := []*string{aws.String(s.(string))}
 			fieldVal.Set(reflect.ValueOf(slice))
+		case []string:
+			fieldVal.Set(reflect.ValueOf(aws.StringSlice(s.([]string))))
+		case []interface{}:
+			var slice []*string
+			for _, item := range s.([]interface{}) {
+				slice = append(slice, aws.String(fmt.Sprint(item)))
+			}
+			fieldVal.Set(reflect.ValueOf(slice))
 		case int64:
 			slice := []*int64{aws.Int64(s.(int64))}
 			fieldVal.Set(reflect.ValueOf(slice))
 		case int:
 			slice := []*int64{aws.Int64(int64(s.(int)))}
 			fieldVal.Set(reflect.ValueOf(slice))
+		case ast.Port:
+			slice := []*int64{aws.Int64(int64(s.(ast.Port)))}
+			fieldVal.Set(reflect.ValueOf(slice))
+		case ast.Color:
+			slice := []*string{aws.String(string(s.(ast.Color)))}
+			fieldVal.Set(reflect.ValueOf(slice))
+		default:
+			panic(fmt.Sprintf("cannot set %T on slice field %s", typed, fieldName))
 		}
 	}
 }
@@ -17,10 +17,12 @@ limitations under the License.
 package aws
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/template/ast"
 )
 
 func TestSetFieldsOnAwsStruct(t *testing.T) {
@@ -45,6 +47,34 @@ func TestSetFieldsOnAwsStruct(t *testing.T) {
 	}
 }
 
+type stringer string
+
+func (s stringer) String() string { return "str:" + string(s) }
+
+func TestSetFieldWithStringer(t *testing.T) {
+	awsparams := &ec2.RunInstancesInput{}
+
+	setField(stringer("ami"), awsparams, "ImageId")
+	setField(stringer("sg"), awsparams, "SecurityGroupIds")
+
+	if got, want := aws.StringValue(awsparams.ImageId), "str:ami"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := aws.StringValue(awsparams.SecurityGroupIds[0]), "str:sg"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	setField([]string{"sg-1", "sg-2"}, awsparams, "SecurityGroups")
+	if got, want := aws.StringValueSlice(awsparams.SecurityGroups), []string{"sg-1", "sg-2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	setField(ast.Null, awsparams, "KeyName")
+	if awsparams.KeyName != nil {
+		t.Fatalf("got %s, want nil", aws.StringValue(awsparams.KeyName))
+	}
+}
+
 func TestSetFieldWithMultiType(t *testing.T) {
 	any := struct {
 		Field             string
@@ -144,6 +174,78 @@ func TestSetFieldWithMultiType(t *testing.T) {
 	}
 }
 
+func TestSetFieldWithTypedValues(t *testing.T) {
+	any := struct {
+		StringField      *string
+		Int64Field       *int64
+		Int64ArrayField  []*int64
+		StringArrayField []*string
+	}{}
+
+	setField(ast.Port(443), &any, "Int64Field")
+	if got, want := aws.Int64Value(any.Int64Field), int64(443); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	setField(float64(12), &any, "Int64Field")
+	if got, want := aws.Int64Value(any.Int64Field), int64(12); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	setField(ast.Size{Value: 10, Unit: "gb"}, &any, "Int64Field")
+	if got, want := aws.Int64Value(any.Int64Field), int64(10); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	setField(ast.Port(22), &any, "Int64ArrayField")
+	if got, want := aws.Int64ValueSlice(any.Int64ArrayField), []int64{22}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	setField(ast.Color("#1a2b3c"), &any, "StringField")
+	if got, want := aws.StringValue(any.StringField), "#1a2b3c"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	setField(ast.Port(8080), &any, "StringField")
+	if got, want := aws.StringValue(any.StringField), "8080"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	setField(0.5, &any, "StringField")
+	if got, want := aws.StringValue(any.StringField), "0.5"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	setField(ast.ResourceID{Prefix: "vpc", Suffix: "1a2b3c4d"}, &any, "StringField")
+	if got, want := aws.StringValue(any.StringField), "vpc-1a2b3c4d"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	setField(ast.Range{Lo: 80, Hi: 443}, &any, "StringArrayField")
+	if got, want := aws.StringValueSlice(any.StringArrayField), []string{"80-443"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	setField(ast.Color("#fff"), &any, "StringArrayField")
+	if got, want := aws.StringValueSlice(any.StringArrayField), []string{"#fff"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	invalids := []struct {
+		value interface{}
+		field string
+	}{
+		{ast.Range{Lo: 80, Hi: 443}, "Int64Field"},
+		{1.5, "Int64Field"},
+		{ast.PercentOf{Percent: 50, Ref: "max"}, "Int64Field"},
+		{[]string{"a", "b"}, "StringField"},
+		{true, "Int64ArrayField"},
+	}
+	for _, invalid := range invalids {
+		func() {
+			defer func() {
+				if panicked := recover(); panicked == nil {
+					t.Fatalf("%T on %s: expected panic to occur", invalid.value, invalid.field)
+				}
+			}()
+			setField(invalid.value, &any, invalid.field)
+		}()
+	}
+}
+
 func TestCanOnlySetFieldOnStructPtr(t *testing.T) {
 	defer func() {
 		if panicked := recover(); panicked == nil {
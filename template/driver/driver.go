@@ -19,7 +19,9 @@ package driver
 import "github.com/wallix/awless/logger"
 
 type Driver interface {
-	Lookup(...string) DriverFn
+	// Lookup returns the function running the action on the entity
+	// (ex: "create", "vpc"), erroring when the driver does not support it
+	Lookup(...string) (DriverFn, error)
 	SetDryRun(bool)
 	SetLogger(*logger.Logger)
 }
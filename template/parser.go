@@ -19,15 +19,12 @@ package template
 import "github.com/wallix/awless/template/ast"
 
 func Parse(text string) (*Template, error) {
-	p := &ast.Peg{AST: &ast.AST{}, Buffer: string(text), Pretty: true}
-	p.Init()
-
-	if err := p.Parse(); err != nil {
+	tree, err := ast.Parse(text)
+	if err != nil {
 		return nil, err
 	}
-	p.Execute()
 
-	return &Template{AST: p.AST}, nil
+	return &Template{AST: tree}, nil
 }
 
 func MustParse(text string) *Template {
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/wallix/awless/template/ast"
@@ -90,6 +91,60 @@ func TestTemplateParsing(t *testing.T) {
 					return nil
 				},
 			},
+			{
+				input: "create vpc\n/* my comment */\ncreate subnet",
+				verifyFn: func(tpl *Template) error {
+					if got, want := len(tpl.Statements), 2; got != want {
+						t.Fatalf("got %d, want %d", got, want)
+					}
+					if err := isExpressionNode(tpl.Statements[1].Node); err != nil {
+						t.Fatal(err)
+					}
+					return nil
+				},
+			},
+			{
+				input: "create vpc\n/* my\n   multiline comment\n create instance */\ncreate subnet",
+				verifyFn: func(tpl *Template) error {
+					if got, want := len(tpl.Statements), 2; got != want {
+						t.Fatalf("got %d, want %d", got, want)
+					}
+					if got, want := tpl.Statements[1].Entity(), "subnet"; got != want {
+						t.Fatalf("got %s, want %s", got, want)
+					}
+					return nil
+				},
+			},
+			{
+				input: "create vpc cidr=10.0.0.0/16 # main vpc\ncreate subnet",
+				verifyFn: func(tpl *Template) error {
+					if got, want := len(tpl.Statements), 2; got != want {
+						t.Fatalf("got %d, want %d", got, want)
+					}
+					if got, want := tpl.Statements[0].Params()["cidr"], "10.0.0.0/16"; got != want {
+						t.Fatalf("got %s, want %s", got, want)
+					}
+					if got, want := len(tpl.Statements[0].Params()), 1; got != want {
+						t.Fatalf("got %d, want %d", got, want)
+					}
+					return nil
+				},
+			},
+			{
+				input: "myvpc = create vpc cidr=10.0.0.0/16// main vpc\ncreate subnet vpc=$myvpc",
+				verifyFn: func(tpl *Template) error {
+					if got, want := len(tpl.Statements), 2; got != want {
+						t.Fatalf("got %d, want %d", got, want)
+					}
+					if err := isDeclarationNode(tpl.Statements[0].Node); err != nil {
+						t.Fatal(err)
+					}
+					if got, want := tpl.Statements[0].Params()["cidr"], "10.0.0.0/16"; got != want {
+						t.Fatalf("got %s, want %s", got, want)
+					}
+					return nil
+				},
+			},
 		}
 
 		for _, tcase := range tcases {
@@ -104,6 +159,108 @@ func TestTemplateParsing(t *testing.T) {
 		}
 	})
 
+	t.Run("Semicolon separated statements", func(t *testing.T) {
+		tcases := []struct {
+			input    string
+			verifyFn func(tpl *Template) error
+		}{
+			{
+				input: "create vpc cidr=10.0.0.0/16; create subnet vpc=$vpc",
+				verifyFn: func(tpl *Template) error {
+					if got, want := len(tpl.Statements), 2; got != want {
+						t.Fatalf("got %d, want %d", got, want)
+					}
+					if err := assertParams(tpl.Statements[0].Node, map[string]interface{}{"cidr": "10.0.0.0/16"}); err != nil {
+						return err
+					}
+					return assertRefs(tpl.Statements[1].Node, map[string]string{"vpc": "vpc"})
+				},
+			},
+			{
+				input: "myvpc = create vpc;create subnet;\ncreate instance;",
+				verifyFn: func(tpl *Template) error {
+					if got, want := len(tpl.Statements), 3; got != want {
+						t.Fatalf("got %d, want %d", got, want)
+					}
+					if err := isDeclarationNode(tpl.Statements[0].Node); err != nil {
+						return err
+					}
+					if got, want := tpl.Statements[2].Entity(), "instance"; got != want {
+						t.Fatalf("got %s, want %s", got, want)
+					}
+					return nil
+				},
+			},
+		}
+
+		for _, tcase := range tcases {
+			node, err := Parse(tcase.input)
+			if err != nil {
+				t.Fatalf("\ninput: [%s]\nError: %s\n", tcase.input, err)
+			}
+
+			if err := tcase.verifyFn(node); err != nil {
+				t.Fatalf("\ninput: [%s]\nError: %s\n", tcase.input, err)
+			}
+		}
+	})
+
+	t.Run("Heredoc values", func(t *testing.T) {
+		tpl, err := Parse("create instance userdata=<<EOF\n#!/bin/sh\n  echo hello\nEOF name=any\ncreate vpc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(tpl.Statements), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if err := assertParams(tpl.Statements[0].Node, map[string]interface{}{"userdata": "#!/bin/sh\n  echo hello", "name": "any"}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Parse("create vpc\ncreate instance userdata=<<EOF\nline1\nEOFX\nline2\n")
+		if err == nil {
+			t.Fatal("expected error got none")
+		}
+		if !strings.Contains(err.Error(), "HeredocStart") || !strings.Contains(err.Error(), "line 2 symbol 26") {
+			t.Fatalf("expected error pointing at heredoc start, got %s", err)
+		}
+	})
+
+	t.Run("Line continuation", func(t *testing.T) {
+		tpl, err := Parse("create instance type=t2.micro \\\n  image=ami-123456\\\r\n  name=any\ncreate vpc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(tpl.Statements), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if err := assertParams(tpl.Statements[0].Node, map[string]interface{}{"type": "t2.micro", "image": "ami-123456", "name": "any"}); err != nil {
+			t.Fatal(err)
+		}
+
+		tpl, err = Parse(`create instance name='my\instance' userdata="a\\b"`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := assertParams(tpl.Statements[0].Node, map[string]interface{}{"name": `my\instance`, "userdata": `a\b`}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = Parse(`create instance name=my\instance`); err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+
+	t.Run("Unterminated block comment", func(t *testing.T) {
+		_, err := Parse("create vpc\n/* my comment\ncreate subnet")
+		if err == nil {
+			t.Fatal("expected error got none")
+		}
+		if !strings.Contains(err.Error(), "BlockCommentStart") || !strings.Contains(err.Error(), "line 2 symbol 1") {
+			t.Fatalf("expected error pointing at block comment start, got %s", err)
+		}
+	})
+
 	t.Run("Onliner statement", func(t *testing.T) {
 		tcases := []struct {
 			input    string
@@ -133,6 +290,12 @@ func TestTemplateParsing(t *testing.T) {
 					return assertRefs(n, map[string]string{"vpc": "myvpc"})
 				},
 			},
+			{
+				input: `create instance subnet=$myinstance.subnet ip=$myinstance.network.privateip`,
+				verifyFn: func(n ast.Node) error {
+					return assertRefs(n, map[string]string{"subnet": "myinstance.subnet", "ip": "myinstance.network.privateip"})
+				},
+			},
 			{
 				input: `create instance subnet=@my-subnet`,
 				verifyFn: func(n ast.Node) error {
@@ -145,10 +308,34 @@ func TestTemplateParsing(t *testing.T) {
 					return assertHoles(n, map[string]string{"id": "my-vpc-id"})
 				},
 			},
+			{
+				input: `create instance count={ instance.count:int } subnet={instance.subnet}`,
+				verifyFn: func(n ast.Node) error {
+					if err := assertHoles(n, map[string]string{"count": "instance.count", "subnet": "instance.subnet"}); err != nil {
+						return err
+					}
+					if got, want := n.(*ast.ExpressionNode).HoleTypes, map[string]string{"count": "int"}; !reflect.DeepEqual(got, want) {
+						return fmt.Errorf("hole types: got %#v, want %#v", got, want)
+					}
+					return nil
+				},
+			},
+			{
+				input: `create policy document='{"Effect": "Allow", "Condition": "a=b"}' name='my policy'`,
+				verifyFn: func(n ast.Node) error {
+					return assertParams(n, map[string]interface{}{"document": `{"Effect": "Allow", "Condition": "a=b"}`, "name": "my policy"})
+				},
+			},
+			{
+				input: `create tags key="it's mine" value="with \"escaped\" quotes; and semicolon" empty=''`,
+				verifyFn: func(n ast.Node) error {
+					return assertParams(n, map[string]interface{}{"key": "it's mine", "value": `with "escaped" quotes; and semicolon`, "empty": ""})
+				},
+			},
 			{
 				input: `create securitygroup port=20-80`,
 				verifyFn: func(n ast.Node) error {
-					if err := assertParams(n, map[string]interface{}{"port": "20-80"}); err != nil {
+					if err := assertParams(n, map[string]interface{}{"port": ast.Range{Lo: 20, Hi: 80}}); err != nil {
 						return err
 					}
 					return nil
@@ -238,7 +425,7 @@ create instance count=1 instance.type=t2.micro subnet=$mysubnet image=ami-9398d3
 
 					err = assertExpressionNode(s.Statements[2].Node, "create", "instance",
 						map[string]string{"subnet": "mysubnet"},
-						map[string]interface{}{"count": 1, "instance.type": "t2.micro", "ip": "127.0.0.1", "image": "ami-9398d3e0"},
+						map[string]interface{}{"count": 1, "instance.type": "t2.micro", "ip": "127.0.0.1", "image": ast.ResourceID{Prefix: "ami", Suffix: "9398d3e0"}},
 						map[string]string{},
 						map[string]string{},
 					)
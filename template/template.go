@@ -19,6 +19,7 @@ package template
 import (
 	"crypto/rand"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -31,36 +32,89 @@ type Template struct {
 	*ast.AST
 }
 
+// Run runs the statements of the template in order with the driver,
+// skipping the ones whose guard is false
 func (s *Template) Run(d driver.Driver) (*Template, error) {
 	vars := map[string]interface{}{}
 
 	current := &Template{AST: s.Clone()}
 
+	if err := current.ResolveEnv(os.LookupEnv); err != nil {
+		return current, err
+	}
+	if err := current.ResolveParamRefs(); err != nil {
+		return current, err
+	}
+
 	for _, sts := range current.Statements {
+		if sts.Guard != nil {
+			run, err := sts.Guard.Eval(nil)
+			if err != nil {
+				sts.Err = fmt.Errorf("line %d: %s", sts.LineNumber, err)
+				return current, sts.Err
+			}
+			if !run {
+				continue
+			}
+		}
 		switch sts.Node.(type) {
+		case *ast.VarNode:
+			node := sts.Node.(*ast.VarNode)
+			vars[node.Ident] = node.Value
+			// vars have no result: $_ still refers to the previous statement
+			continue
 		case *ast.ExpressionNode:
 			expr := sts.Node.(*ast.ExpressionNode)
-			fn := d.Lookup(expr.Action, expr.Entity)
-			expr.ProcessRefs(vars)
+			fn, err := d.Lookup(expr.Action, expr.Entity)
+			if err != nil {
+				sts.Err = err
+				return current, sts.Err
+			}
+			if unresolved := expr.ProcessRefs(vars); len(unresolved) > 0 {
+				sts.Err = unresolvedRefsError(sts.LineNumber, expr, unresolved)
+				return current, sts.Err
+			}
 
 			sts.Line = expr.String()
 			if sts.Result, sts.Err = fn(expr.Params); sts.Err != nil {
 				return current, sts.Err
 			}
 		case *ast.DeclarationNode:
-			ident := sts.Node.(*ast.DeclarationNode).Left
-			expr := sts.Node.(*ast.DeclarationNode).Right
-			fn := d.Lookup(expr.Action, expr.Entity)
-			expr.ProcessRefs(vars)
+			decl := sts.Node.(*ast.DeclarationNode)
+			expr := decl.Right
+			fn, err := d.Lookup(expr.Action, expr.Entity)
+			if err != nil {
+				sts.Err = err
+				return current, sts.Err
+			}
+			if unresolved := expr.ProcessRefs(vars); len(unresolved) > 0 {
+				sts.Err = unresolvedRefsError(sts.LineNumber, expr, unresolved)
+				return current, sts.Err
+			}
 
 			sts.Result, sts.Err = fn(expr.Params)
-			ident.Val = sts.Result
 			sts.Line = expr.String()
 			if sts.Err != nil {
 				return current, sts.Err
 			}
-			vars[ident.Ident] = ident.Val
+			outputs := []interface{}{sts.Result}
+			if len(decl.Extra) > 0 {
+				results, ok := sts.Result.([]interface{})
+				if !ok || len(results) != len(decl.Extra)+1 {
+					sts.Err = fmt.Errorf("%s %s: expecting %d outputs to assign, got %v", expr.Action, expr.Entity, len(decl.Extra)+1, sts.Result)
+					return current, sts.Err
+				}
+				outputs = results
+			}
+			for i, ident := range decl.Idents() {
+				ident.Val = outputs[i]
+				vars[ident.Ident] = ident.Val
+			}
+		case *ast.IncludeNode:
+			sts.Err = fmt.Errorf("line %d: %s: unresolved include", sts.LineNumber, sts.Node)
+			return current, sts.Err
 		}
+		vars[ast.PreviousResultRef] = sts.Result
 	}
 
 	return current, nil
@@ -93,6 +147,19 @@ func (s *Template) GetHolesValuesSet() (values []string) {
 	return
 }
 
+func (s *Template) GetHolesTypes() map[string]string {
+	types := make(map[string]string)
+	each := func(expr *ast.ExpressionNode) {
+		for key, typ := range expr.HoleTypes {
+			if hole, ok := expr.Holes[key]; ok {
+				types[hole] = typ
+			}
+		}
+	}
+	s.visitExpressionNodes(each)
+	return types
+}
+
 func (s *Template) GetNormalizedAliases() map[string]string {
 	aliases := make(map[string]string)
 	each := func(expr *ast.ExpressionNode) {
@@ -131,12 +198,25 @@ func (s *Template) MergeParams(newParams map[string]interface{}) {
 					expr.Params = make(map[string]interface{})
 				}
 				expr.Params[strings.SplitN(k, ".", 2)[1]] = v
+				delete(expr.Raw, strings.SplitN(k, ".", 2)[1])
 			}
 		}
 	}
 	s.visitExpressionNodes(each)
 }
 
+// unresolvedRefsError names the refs of the expression params of the
+// given keys that could not be resolved
+func unresolvedRefsError(line int, expr *ast.ExpressionNode, keys []string) error {
+	var refs []string
+	for _, k := range keys {
+		refs = append(refs, "$"+expr.Refs[k])
+	}
+	return fmt.Errorf("line %d: %s %s: unresolved %s", line, expr.Action, expr.Entity, strings.Join(refs, ", "))
+}
+
+// ResolveHoles fills the holes of the template with the given values.
+// Guards whose hole is filled are evaluated and replaced by their result.
 func (s *Template) ResolveHoles(refs ...map[string]interface{}) (map[string]interface{}, error) {
 	all := make(map[string]interface{})
 	for _, ref := range refs {
@@ -146,8 +226,12 @@ func (s *Template) ResolveHoles(refs ...map[string]interface{}) (map[string]inte
 	}
 
 	resolved := make(map[string]interface{})
+	var err error
 	each := func(expr *ast.ExpressionNode) {
-		processed := expr.ProcessHoles(all)
+		processed, perr := expr.ProcessHoles(all)
+		if perr != nil && err == nil {
+			err = fmt.Errorf("%s %s: %s", expr.Action, expr.Entity, perr)
+		}
 		for key, v := range processed {
 			resolved[expr.Entity+"."+key] = v
 		}
@@ -155,7 +239,24 @@ func (s *Template) ResolveHoles(refs ...map[string]interface{}) (map[string]inte
 
 	s.visitExpressionNodes(each)
 
-	return resolved, nil
+	for _, st := range s.Statements {
+		if st.Guard == nil || st.Guard.Hole == "" {
+			continue
+		}
+		if _, ok := all[st.Guard.Hole]; !ok {
+			continue
+		}
+		run, gerr := st.Guard.Eval(all)
+		if gerr != nil {
+			if err == nil {
+				err = gerr
+			}
+			continue
+		}
+		st.Guard = &ast.Guard{Value: run}
+	}
+
+	return resolved, err
 }
 
 func (s *Template) visitExpressionNodes(fn func(n *ast.ExpressionNode)) {
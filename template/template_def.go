@@ -26,8 +26,31 @@ type LookupTemplateDefFunc func(key string) (TemplateDefinition, bool)
 type TemplateDefinition struct {
 	Action, Entity, Api                      string
 	RequiredParams, ExtraParams, TagsMapping []string
+	// ParamTypes declares the expected type of the values
+	// of some params (ex: "cidr": CidrParam)
+	ParamTypes map[string]string
 }
 
+// EnsureAction is the idempotent variant of create (ex: ensure instance):
+// executors create the resource only if absent. It is accepted for every
+// entity that has a create definition, but runs only with drivers
+// implementing it: the lookup fails otherwise (ex: with the aws driver).
+const EnsureAction = "ensure"
+
+// definitionAction returns the action of the definitions of statements
+func definitionAction(action string) string {
+	if action == EnsureAction {
+		return "create"
+	}
+	return action
+}
+
+// Param types checked by ValidateAgainst
+const (
+	CidrParam = "cidr"
+	IPParam   = "ip"
+)
+
 func (def TemplateDefinition) Name() string {
 	return fmt.Sprintf("%s%s", def.Action, def.Entity)
 }
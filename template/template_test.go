@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/oklog/ulid"
@@ -30,8 +31,8 @@ import (
 
 type noopDriver struct{}
 
-func (d *noopDriver) Lookup(lookups ...string) driver.DriverFn {
-	return func(map[string]interface{}) (interface{}, error) { return nil, nil }
+func (d *noopDriver) Lookup(lookups ...string) (driver.DriverFn, error) {
+	return func(map[string]interface{}) (interface{}, error) { return nil, nil }, nil
 }
 func (d *noopDriver) SetLogger(*logger.Logger) {}
 func (d *noopDriver) SetDryRun(bool)           {}
@@ -40,8 +41,8 @@ type errorDriver struct {
 	err error
 }
 
-func (d *errorDriver) Lookup(lookups ...string) driver.DriverFn {
-	return func(map[string]interface{}) (interface{}, error) { return nil, d.err }
+func (d *errorDriver) Lookup(lookups ...string) (driver.DriverFn, error) {
+	return func(map[string]interface{}) (interface{}, error) { return nil, d.err }, nil
 }
 func (d *errorDriver) SetLogger(*logger.Logger) {}
 func (d *errorDriver) SetDryRun(bool)           {}
@@ -322,6 +323,131 @@ func TestRunDriverOnTemplate(t *testing.T) {
 		}
 	})
 
+	t.Run("Driver run multiple assignment", func(t *testing.T) {
+		s, err := Parse("mykey, mysecret = create accesskey user=jdoe\ncreate tags key=$mykey value=$mysecret")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mDriver := &mockDriver{expects: []*expectation{{
+			action: "create", entity: "accesskey",
+			expectedParams: map[string]interface{}{"user": "jdoe"},
+		}, {
+			action: "create", entity: "tags",
+			expectedParams: map[string]interface{}{"key": "AKIA", "value": "s3cr3t"},
+		}},
+			outputs: map[string]interface{}{"accesskey": []interface{}{"AKIA", "s3cr3t"}},
+		}
+
+		if _, err := s.Run(mDriver); err != nil {
+			t.Fatal(err)
+		}
+		if err := mDriver.lookupsCalled(); err != nil {
+			t.Fatal(err)
+		}
+
+		mDriver.outputs["accesskey"] = "AKIA"
+		if _, err := s.Run(mDriver); err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+
+	t.Run("Driver run previous statement result", func(t *testing.T) {
+		s, err := Parse("create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$_\ncreate instance subnet=$_")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mDriver := &mockDriver{prefix: "mynew", expects: []*expectation{{
+			action: "create", entity: "vpc",
+			expectedParams: map[string]interface{}{"cidr": "10.0.0.0/16"},
+		}, {
+			action: "create", entity: "subnet",
+			expectedParams: map[string]interface{}{"vpc": "mynewvpc"},
+		}, {
+			action: "create", entity: "instance",
+			expectedParams: map[string]interface{}{"subnet": "mynewsubnet"},
+		}},
+		}
+
+		if _, err := s.Run(mDriver); err != nil {
+			t.Fatal(err)
+		}
+		if err := mDriver.lookupsCalled(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Driver run vars", func(t *testing.T) {
+		s, err := Parse("create vpc cidr=10.0.0.0/16\nvar net=10.0.0.0/24, zone=eu-west-1a\ncreate subnet cidr=$net zone=$zone vpc=$_")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mDriver := &mockDriver{prefix: "mynew", expects: []*expectation{{
+			action: "create", entity: "vpc",
+			expectedParams: map[string]interface{}{"cidr": "10.0.0.0/16"},
+		}, {
+			action: "create", entity: "subnet",
+			expectedParams: map[string]interface{}{"cidr": "10.0.0.0/24", "zone": "eu-west-1a", "vpc": "mynewvpc"},
+		}},
+		}
+
+		if _, err := s.Run(mDriver); err != nil {
+			t.Fatal(err)
+		}
+		if err := mDriver.lookupsCalled(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Driver run unresolved refs", func(t *testing.T) {
+		s, err := Parse("create vpc cidr=10.0.0.0/16\nvar net=10.0.0.0/24\nmysubnet = create subnet cidr=$net vpc=$myvpc")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mDriver := &mockDriver{prefix: "mynew", expects: []*expectation{{
+			action: "create", entity: "vpc",
+			expectedParams: map[string]interface{}{"cidr": "10.0.0.0/16"},
+		}}}
+
+		ran, err := s.Run(mDriver)
+		if err == nil || err.Error() != "line 3: create subnet: unresolved $myvpc" {
+			t.Fatalf("got %v, want unresolved ref error", err)
+		}
+		if got, want := ran.Statements[2].Err, err; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Driver run guarded statements", func(t *testing.T) {
+		s, err := Parse("when false delete instance id=i-12345678\nwhen {large} create instance type=t2.large\nwhen {count} > 1 create vpc cidr=10.0.0.0/16\nwhen true create subnet cidr=10.0.0.0/24")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Run(&mockDriver{}); err == nil || !strings.Contains(err.Error(), "line 2: guard {large}: hole 'large' not filled") {
+			t.Fatalf("got %v, want unfilled guard error", err)
+		}
+
+		if _, err := s.ResolveHoles(map[string]interface{}{"large": "false", "count": 2}); err != nil {
+			t.Fatal(err)
+		}
+		mDriver := &mockDriver{expects: []*expectation{{
+			action: "create", entity: "vpc",
+			expectedParams: map[string]interface{}{"cidr": "10.0.0.0/16"},
+		}, {
+			action: "create", entity: "subnet",
+			expectedParams: map[string]interface{}{"cidr": "10.0.0.0/24"},
+		}}}
+		if _, err := s.Run(mDriver); err != nil {
+			t.Fatal(err)
+		}
+		if err := mDriver.lookupsCalled(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
 	t.Run("Driver visit expression nodes", func(t *testing.T) {
 		s := &Template{AST: &ast.AST{}}
 
@@ -376,6 +502,16 @@ func TestRunDriverOnTemplate(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
+
+	t.Run("Unresolved include", func(t *testing.T) {
+		s, err := Parse("include \"vpc.aws\"")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Run(&mockDriver{}); err == nil || !strings.Contains(err.Error(), "unresolved include") {
+			t.Fatalf("got %v, want unresolved include error", err)
+		}
+	})
 }
 
 func TestGetNormalisedAliases(t *testing.T) {
@@ -511,6 +647,27 @@ func TestResolveHoles(t *testing.T) {
 	}
 }
 
+func TestResolveTypedHoles(t *testing.T) {
+	tpl := MustParse("create instance count={instance.count:int} subnet={instance.subnet}")
+
+	if got, want := tpl.GetHolesTypes(), map[string]string{"instance.count": "int"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := (&Template{AST: tpl.Clone()}).ResolveHoles(map[string]interface{}{"instance.count": "abc"}); err == nil {
+		t.Fatal("expected error got none")
+	}
+
+	filled, err := tpl.ResolveHoles(map[string]interface{}{"instance.count": "5", "instance.subnet": "sub-12345"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"instance.count": 5, "instance.subnet": "sub-12345"}
+	if got, want := filled, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
 type expectation struct {
 	lookupDone     bool
 	action, entity string
@@ -520,6 +677,7 @@ type expectation struct {
 type mockDriver struct {
 	expects []*expectation
 	prefix  string
+	outputs map[string]interface{}
 }
 
 func (r *mockDriver) lookupsCalled() error {
@@ -532,7 +690,7 @@ func (r *mockDriver) lookupsCalled() error {
 	return nil
 }
 
-func (r *mockDriver) Lookup(lookups ...string) driver.DriverFn {
+func (r *mockDriver) Lookup(lookups ...string) (driver.DriverFn, error) {
 	for _, expect := range r.expects {
 		if lookups[0] == expect.action && lookups[1] == expect.entity {
 			expect.lookupDone = true
@@ -541,14 +699,17 @@ func (r *mockDriver) Lookup(lookups ...string) driver.DriverFn {
 				if got, want := expect.expectedParams, params; !reflect.DeepEqual(got, want) {
 					return nil, fmt.Errorf("[%s %s] params mismatch: expected %v, got %v", expect.action, expect.entity, got, want)
 				}
+				if out, ok := r.outputs[expect.entity]; ok {
+					return out, nil
+				}
 				return r.prefix + expect.entity, nil
-			}
+			}, nil
 		}
 	}
 
 	return func(params map[string]interface{}) (interface{}, error) {
 		return nil, errors.New("Unexpected lookup fallthrough")
-	}
+	}, nil
 }
 
 func (r *mockDriver) SetLogger(*logger.Logger) {}
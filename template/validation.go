@@ -0,0 +1,229 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"net"
+	"sort"
+
+	"github.com/wallix/awless/template/ast"
+)
+
+// DefinitionsRegistry holds the known template definitions
+// keyed by action and entity (ex: "createvpc")
+type DefinitionsRegistry map[string]TemplateDefinition
+
+func (r DefinitionsRegistry) Lookup(key string) (TemplateDefinition, bool) {
+	def, ok := r[key]
+	return def, ok
+}
+
+func (r DefinitionsRegistry) hasAction(action string) bool {
+	for _, def := range r {
+		if def.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+func (r DefinitionsRegistry) hasEntity(entity string) bool {
+	for _, def := range r {
+		if def.Entity == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidationCheck enables optional checks of ValidateAgainst
+type ValidationCheck int
+
+const (
+	// CheckRefEntities checks that references point to declarations of
+	// the expected entity: the statement entity for 'id' params
+	// (ex: delete subnet id=$mysubnet) or the entity named by the param
+	// key (ex: create subnet vpc=$myvpc)
+	CheckRefEntities ValidationCheck = iota
+)
+
+// ValidateAgainst checks every statement of the template against the registry
+// and returns all the errors found, in statement order, as *ast.ValidationError.
+// Holes must be used with a single type across the template.
+// Checks disabled on a statement by an "# awless:disable" comment are skipped.
+func (s *Template) ValidateAgainst(r DefinitionsRegistry, checks ...ValidationCheck) (errs []error) {
+	var checkRefs bool
+	for _, c := range checks {
+		if c == CheckRefEntities {
+			checkRefs = true
+		}
+	}
+
+	declared := make(map[string]string)
+	holes := make(map[string]holeUsage)
+	for _, st := range s.Statements {
+		if decl, ok := st.Node.(*ast.DeclarationNode); ok {
+			for _, ident := range decl.Idents() {
+				declared[ident.Ident] = decl.Right.Entity
+			}
+		}
+	}
+
+	for _, st := range s.Statements {
+		var expr *ast.ExpressionNode
+		switch n := st.Node.(type) {
+		case *ast.ExpressionNode:
+			expr = n
+		case *ast.DeclarationNode:
+			expr = n.Right
+		default:
+			continue
+		}
+
+		action := definitionAction(expr.Action)
+		if !r.hasAction(action) {
+			errs = append(errs, ast.NewValidationError(st, "unknown-action", "%s %s: unknown action '%s'", expr.Action, expr.Entity, expr.Action))
+			continue
+		}
+		if !r.hasEntity(expr.Entity) {
+			errs = append(errs, ast.NewValidationError(st, "unknown-entity", "%s %s: unknown entity '%s'", expr.Action, expr.Entity, expr.Entity))
+			continue
+		}
+		def, ok := r.Lookup(action + expr.Entity)
+		if !ok {
+			errs = append(errs, ast.NewValidationError(st, "unsupported", "%s %s: unsupported action/entity", expr.Action, expr.Entity))
+			continue
+		}
+		for _, key := range def.Required() {
+			if !hasParamKey(expr, key) {
+				errs = append(errs, ast.NewValidationError(st, "missing-param", "%s %s: missing required param '%s'", expr.Action, expr.Entity, key))
+			}
+		}
+		for _, key := range sortedKeys(def.ParamTypes) {
+			if _, ok := expr.Params[key].(ast.Interpolation); ok || expr.Matches[key] {
+				continue
+			}
+			if val, ok := expr.Params[key]; ok && !isParamType(val, def.ParamTypes[key]) {
+				errs = append(errs, ast.NewValidationError(st, "param-type", "%s %s: param '%s': '%v' is not a valid %s", expr.Action, expr.Entity, key, val, def.ParamTypes[key]))
+			}
+		}
+		for _, key := range sortedKeys(expr.Holes) {
+			hole := expr.Holes[key]
+			typ, expected := expr.HoleTypes[key], def.ParamTypes[key]
+			if typ != "" && expected != "" && typ != expected {
+				errs = append(errs, ast.NewValidationError(st, "hole-type", "%s %s: hole '%s' typed %s for param '%s' expecting a %s", expr.Action, expr.Entity, hole, typ, key, expected))
+				continue
+			}
+			if typ == "" {
+				typ = expected
+			}
+			if typ == "" {
+				continue
+			}
+			if first, ok := holes[hole]; !ok {
+				holes[hole] = holeUsage{typ: typ, line: st.LineNumber}
+			} else if first.typ != typ {
+				errs = append(errs, ast.NewValidationError(st, "hole-type", "%s %s: hole '%s' used as %s for param '%s' but as %s line %d", expr.Action, expr.Entity, hole, typ, key, first.typ, first.line))
+			}
+		}
+		if checkRefs {
+			for _, key := range sortedKeys(expr.Refs) {
+				ref := ast.ParseRefPath(expr.Refs[key])
+				target, ok := declared[ref.Name]
+				if !ok || ref.Attr != "" {
+					continue
+				}
+				expected := key
+				if key == "id" {
+					expected = expr.Entity
+				}
+				if r.hasEntity(expected) && target != expected {
+					errs = append(errs, ast.NewValidationError(st, "ref-entity", "%s %s: param '%s' references '%s' which is a %s, expecting a %s", expr.Action, expr.Entity, key, ref.Name, target, expected))
+				}
+			}
+		}
+	}
+
+	return ast.WithoutDisabled(errs)
+}
+
+// holeUsage is the type a hole is first used with: the type it is
+// annotated with (ex: {size:int}) or else the type of its param
+type holeUsage struct {
+	typ  string
+	line int
+}
+
+// isParamType checks literal values only: refs, aliases, holes and
+// environment variables are not in params until resolved. Lists are
+// checked item by item (ex: cidr=10.0.0.0/8,10.1.0.0/8)
+func isParamType(val interface{}, typ string) bool {
+	switch items := val.(type) {
+	case []string:
+		for _, item := range items {
+			if !isParamType(item, typ) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		for _, item := range items {
+			if item != nil && !isParamType(item, typ) {
+				return false
+			}
+		}
+		return true
+	}
+	str, ok := val.(string)
+	switch typ {
+	case CidrParam:
+		_, _, err := net.ParseCIDR(str)
+		return ok && err == nil
+	case IPParam:
+		return ok && net.ParseIP(str) != nil
+	}
+	return true
+}
+
+func sortedKeys(m map[string]string) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// A param key is considered provided whether given as a value, a reference,
+// an alias, an environment variable or a hole (as holes will be filled before running)
+func hasParamKey(expr *ast.ExpressionNode, key string) bool {
+	if _, ok := expr.Params[key]; ok {
+		return true
+	}
+	if _, ok := expr.Refs[key]; ok {
+		return true
+	}
+	if _, ok := expr.Holes[key]; ok {
+		return true
+	}
+	if _, ok := expr.Aliases[key]; ok {
+		return true
+	}
+	if _, ok := expr.Envs[key]; ok {
+		return true
+	}
+	return false
+}
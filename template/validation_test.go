@@ -0,0 +1,293 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/ast"
+	"github.com/wallix/awless/template/driver/aws"
+)
+
+func TestValidateRequiredParams(t *testing.T) {
+	registry := template.DefinitionsRegistry{
+		"createinstance": template.TemplateDefinition{Action: "create", Entity: "instance", RequiredParams: []string{"type", "image"}},
+		"createsubnet":   template.TemplateDefinition{Action: "create", Entity: "subnet", RequiredParams: []string{"vpc"}},
+		"deletesubnet":   template.TemplateDefinition{Action: "delete", Entity: "subnet", RequiredParams: []string{"id"}},
+	}
+
+	tcases := []struct {
+		input  string
+		errors []string
+	}{
+		{input: "create instance type=t2.micro image=ami-123456"},
+		{input: "create instance type={instance.type} image=ami-123456"},
+		{input: "inst = create instance type=t2.micro image={instance.image}"},
+		{input: "create subnet vpc=$myvpc"},
+		{input: "create subnet vpc=@my-vpc"},
+		{
+			input:  "create instance type=t2.micro",
+			errors: []string{"create instance: missing required param 'image'"},
+		},
+		{
+			input:  "create instance\ncreate subnet",
+			errors: []string{"create instance: missing required param 'type'", "create instance: missing required param 'image'", "create subnet: missing required param 'vpc'"},
+		},
+		{
+			input:  "delete instance id=i-54g3hj",
+			errors: []string{"delete instance: unsupported action/entity"},
+		},
+	}
+
+	for _, tcase := range tcases {
+		errs := template.MustParse(tcase.input).ValidateAgainst(registry)
+		if got, want := len(errs), len(tcase.errors); got != want {
+			t.Fatalf("%s: got %d errors (%v), want %d", tcase.input, got, errs, want)
+		}
+		for i, err := range errs {
+			if got, want := err.Error(), tcase.errors[i]; got != want {
+				t.Fatalf("%s: got %s, want %s", tcase.input, got, want)
+			}
+		}
+	}
+}
+
+func TestValidationErrorsLocateStatements(t *testing.T) {
+	registry := template.DefinitionsRegistry{
+		"createinstance": template.TemplateDefinition{Action: "create", Entity: "instance", RequiredParams: []string{"type"}},
+	}
+
+	tpl := template.MustParse("create instance type=t2.micro\n\ninst = create instance name=any")
+	errs := tpl.ValidateAgainst(registry)
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	verr, ok := errs[0].(*ast.ValidationError)
+	if !ok {
+		t.Fatalf("got %T, want *ast.ValidationError", errs[0])
+	}
+	if got, want := verr.Statement, tpl.Statements[1]; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := verr.Line, 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := verr.Kind, "missing-param"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestValidateRefEntities(t *testing.T) {
+	registry := template.DefinitionsRegistry{
+		"createvpc":    template.TemplateDefinition{Action: "create", Entity: "vpc"},
+		"createsubnet": template.TemplateDefinition{Action: "create", Entity: "subnet", RequiredParams: []string{"vpc"}},
+		"deletesubnet": template.TemplateDefinition{Action: "delete", Entity: "subnet", RequiredParams: []string{"id"}},
+	}
+
+	tpl := template.MustParse("myvpc = create vpc\nmysubnet = create subnet vpc=$myvpc\ndelete subnet id=$mysubnet")
+	if errs := tpl.ValidateAgainst(registry, template.CheckRefEntities); len(errs) != 0 {
+		t.Fatalf("expected no error, got %v", errs)
+	}
+
+	tpl = template.MustParse("myvpc = create vpc\nmysubnet = create subnet vpc=$myvpc\ncreate subnet vpc=$mysubnet\ndelete subnet id=$myvpc")
+	if errs := tpl.ValidateAgainst(registry); len(errs) != 0 {
+		t.Fatalf("expected no error without check, got %v", errs)
+	}
+	errs := tpl.ValidateAgainst(registry, template.CheckRefEntities)
+	if got, want := len(errs), 2; got != want {
+		t.Fatalf("got %d (%v), want %d", got, errs, want)
+	}
+	if got, want := errs[0].Error(), "create subnet: param 'vpc' references 'mysubnet' which is a subnet, expecting a vpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := errs[1].Error(), "delete subnet: param 'id' references 'myvpc' which is a vpc, expecting a subnet"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestValidateActionsAgainstDefaultRegistry(t *testing.T) {
+	for _, action := range []string{"create", "delete", "start", "stop", "update", "attach", "detach", "check"} {
+		if _, err := template.Parse(action + " instance"); err != nil {
+			t.Fatalf("%s: %s", action, err)
+		}
+	}
+
+	tpl, err := template.Parse("reboot instance id=i-54g3hj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := tpl.ValidateAgainst(aws.AWSTemplatesDefinitions)
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := errs[0].Error(), "reboot instance: unknown action 'reboot'"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestValidateEnsureAction(t *testing.T) {
+	tpl, err := template.Parse("ensure instance type=t2.micro image=ami-123456 subnet=$mysubnet count=1\nensure vpc cidr=10.0.0.0/16\nensure instance type=t2.micro image=ami-123456 count=1\nensure loadbalancer name=mylb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tpl.Statements[0].Action(), "ensure"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	var got []string
+	for _, err := range tpl.ValidateAgainst(aws.AWSTemplatesDefinitions) {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"ensure instance: missing required param 'subnet'",
+		"ensure loadbalancer: unknown entity 'loadbalancer'",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidateEntitiesAgainstDefaultRegistry(t *testing.T) {
+	tpl, err := template.Parse("create loadbalancer name=mylb\ncreate vpc cidr=10.0.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tpl.Statements[0].Entity(), "loadbalancer"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	errs := tpl.ValidateAgainst(aws.AWSTemplatesDefinitions)
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := errs[0].Error(), "create loadbalancer: unknown entity 'loadbalancer'"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestValidateParamTypes(t *testing.T) {
+	tcases := []struct {
+		input  string
+		errors []string
+	}{
+		{input: "create subnet cidr=10.0.0.0/24 vpc=$myvpc"},
+		{input: "create subnet cidr={subnet.cidr} vpc=$myvpc"},
+		{input: "create instance image=ami-123456 count=1 type=t2.micro subnet=$mysubnet ip=10.0.0.12"},
+		{input: "create subnet cidr=10.0.0.0/8,10.1.0.0/8 vpc=$myvpc"},
+		{input: "create subnet cidr=[10.0.0.0/8, $othercidr, {subnet.cidr}] vpc=$myvpc"},
+		{
+			input:  "create subnet cidr=10.0.0.0/8,abc vpc=$myvpc",
+			errors: []string{"create subnet: param 'cidr': '[10.0.0.0/8 abc]' is not a valid cidr"},
+		},
+		{
+			input:  "create subnet cidr=[10.0.0.0/8, 10.0.0.1] vpc=$myvpc",
+			errors: []string{"create subnet: param 'cidr': '[10.0.0.0/8 10.0.0.1]' is not a valid cidr"},
+		},
+		{
+			input:  "create subnet cidr=abc vpc=$myvpc",
+			errors: []string{"create subnet: param 'cidr': 'abc' is not a valid cidr"},
+		},
+		{
+			input:  "create instance image=ami-123456 count=1 type=t2.micro subnet=$mysubnet ip=10.0.0.0/24",
+			errors: []string{"create instance: param 'ip': '10.0.0.0/24' is not a valid ip"},
+		},
+	}
+
+	for _, tcase := range tcases {
+		errs := template.MustParse(tcase.input).ValidateAgainst(aws.AWSTemplatesDefinitions)
+		if got, want := len(errs), len(tcase.errors); got != want {
+			t.Fatalf("%s: got %d errors (%v), want %d", tcase.input, got, errs, want)
+		}
+		for i, want := range tcase.errors {
+			if got := errs[i].Error(); got != want {
+				t.Fatalf("%s: got %s, want %s", tcase.input, got, want)
+			}
+			if got, want := errs[i].(*ast.ValidationError).Kind, "param-type"; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+		}
+	}
+}
+
+func TestValidateHoleTypes(t *testing.T) {
+	tcases := []struct {
+		input  string
+		errors []string
+	}{
+		{input: "create subnet cidr={net} vpc=$myvpc\ncreate subnet cidr={net:cidr} vpc=$othervpc\ncreate instance image=ami-123456 count={net} type=t2.micro subnet=$mysubnet"},
+		{input: "create instance image=ami-123456 count=1 type=t2.micro subnet=$mysubnet ip={addr}\ncreate instance image=ami-123456 count=1 type=t2.micro subnet=$othersubnet ip={addr:ip}"},
+		{
+			input:  "create subnet cidr={net} vpc=$myvpc\ncreate instance image=ami-123456 count=1 type=t2.micro subnet=$mysubnet ip={net}",
+			errors: []string{"create instance: hole 'net' used as ip for param 'ip' but as cidr line 1"},
+		},
+		{
+			input:  "create instance image=ami-123456 count={size:int} type=t2.micro subnet=$mysubnet\ncreate subnet cidr={size} vpc=$myvpc",
+			errors: []string{"create subnet: hole 'size' used as cidr for param 'cidr' but as int line 1"},
+		},
+		{
+			input:  "create subnet cidr={net:int} vpc=$myvpc",
+			errors: []string{"create subnet: hole 'net' typed int for param 'cidr' expecting a cidr"},
+		},
+	}
+
+	for _, tcase := range tcases {
+		errs := template.MustParse(tcase.input).ValidateAgainst(aws.AWSTemplatesDefinitions)
+		if got, want := len(errs), len(tcase.errors); got != want {
+			t.Fatalf("%s: got %d errors (%v), want %d", tcase.input, got, errs, want)
+		}
+		for i, want := range tcase.errors {
+			if got := errs[i].Error(); got != want {
+				t.Fatalf("%s: got %s, want %s", tcase.input, got, want)
+			}
+			if got, want := errs[i].(*ast.ValidationError).Kind, "hole-type"; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+		}
+	}
+}
+
+func TestValidateDisabledChecks(t *testing.T) {
+	tpl, err := template.Parse("# awless:disable missing-param\ncreate instance type=t2.micro image=ami-123456 count=1\n\ncreate instance type=t2.micro image=ami-123456 count=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tpl.Statements[0].DisabledChecks, []string{"missing-param"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	errs := tpl.ValidateAgainst(aws.AWSTemplatesDefinitions)
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	verr := errs[0].(*ast.ValidationError)
+	if got, want := verr.Line, 4; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := verr.Kind, "missing-param"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	src := "create vpc cidr=10.0.0.0/16\n\n# awless:disable missing-param,param-type\ncreate subnet cidr=10.0.0.0/24"
+	if tpl, err = template.Parse(src); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tpl.String(), src; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}